@@ -0,0 +1,193 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StatusCheck is a status check that must pass before merging.
+type StatusCheck struct {
+	// Context: the name of the required check.
+	Context string `json:"context"`
+
+	// AppId: the id of the GitHub App that must provide the check, or -1
+	// to accept the check from any app. If not set, GitHub picks the app
+	// that most recently provided the check.
+	// +optional
+	AppId *int64 `json:"appId,omitempty"`
+}
+
+// RequiredStatusChecks defines the status checks that must pass before merging.
+type RequiredStatusChecks struct {
+	// Strict: whether branches must be up to date before merging.
+	// +optional
+	Strict bool `json:"strict,omitempty"`
+
+	// Checks: the list of required status checks.
+	// +optional
+	Checks []StatusCheck `json:"checks,omitempty"`
+}
+
+// Actors is a list of users, teams and apps.
+type Actors struct {
+	// Users: the logins of the users.
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// Teams: the slugs of the teams.
+	// +optional
+	Teams []string `json:"teams,omitempty"`
+
+	// Apps: the slugs of the GitHub Apps.
+	// +optional
+	Apps []string `json:"apps,omitempty"`
+}
+
+// RequiredPullRequestReviews defines the reviews required before merging.
+type RequiredPullRequestReviews struct {
+	// RequiredApprovingReviewCount: the number of approving reviews
+	// required to merge a pull request.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=6
+	RequiredApprovingReviewCount int `json:"requiredApprovingReviewCount"`
+
+	// RequireCodeOwnerReviews: whether an approving review from a code owner is required.
+	// +optional
+	RequireCodeOwnerReviews bool `json:"requireCodeOwnerReviews,omitempty"`
+
+	// DismissStaleReviews: whether approving reviews are dismissed when new commits are pushed.
+	// +optional
+	DismissStaleReviews bool `json:"dismissStaleReviews,omitempty"`
+
+	// RequireLastPushApproval: whether the most recent push must be
+	// approved by someone other than the person who pushed it.
+	// +optional
+	RequireLastPushApproval bool `json:"requireLastPushApproval,omitempty"`
+
+	// DismissalRestrictions: the users, teams and apps allowed to dismiss
+	// reviews. Only available for organization-owned repositories.
+	// +optional
+	DismissalRestrictions *Actors `json:"dismissalRestrictions,omitempty"`
+
+	// BypassPullRequestAllowances: the users, teams and apps allowed to
+	// bypass the pull request requirements.
+	// +optional
+	BypassPullRequestAllowances *Actors `json:"bypassPullRequestAllowances,omitempty"`
+}
+
+// BranchProtectionSpec defines the desired state of BranchProtection
+type BranchProtectionSpec struct {
+	// ApiUrl: the baseUrl for the REST API provider.
+	// +optional
+	// +immutable
+	ApiUrl string `json:"apiUrl,omitempty"`
+
+	// Credentials required to authenticate ReST API git server.
+	Credentials *prv1.CredentialSelectors `json:"credentials"`
+
+	// Verbose is true dumps your client requests and responses.
+	// +optional
+	Verbose *bool `json:"verbose,omitempty"`
+
+	// Owner: the account owner of the repository. The name is not case sensitive.
+	// +immutable
+	Owner string `json:"owner"`
+
+	// Repo: the name of the repository without the .git extension. The name is not case sensitive.
+	// +immutable
+	Repo string `json:"repo"`
+
+	// Branch: the name of the branch to protect. Wildcard characters are not allowed.
+	// +immutable
+	Branch string `json:"branch"`
+
+	// RequiredStatusChecks: the status checks that must pass before
+	// merging. If not set, no status checks are required.
+	// +optional
+	RequiredStatusChecks *RequiredStatusChecks `json:"requiredStatusChecks,omitempty"`
+
+	// RequiredPullRequestReviews: the reviews required before merging. If
+	// not set, pull requests are not required.
+	// +optional
+	RequiredPullRequestReviews *RequiredPullRequestReviews `json:"requiredPullRequestReviews,omitempty"`
+
+	// EnforceAdmins: whether the protection applies to administrators too.
+	// +optional
+	EnforceAdmins bool `json:"enforceAdmins,omitempty"`
+
+	// RequiredLinearHistory: whether merge commits are prohibited.
+	// +optional
+	RequiredLinearHistory bool `json:"requiredLinearHistory,omitempty"`
+
+	// AllowForcePushes: whether force pushes are allowed.
+	// +optional
+	AllowForcePushes bool `json:"allowForcePushes,omitempty"`
+
+	// AllowDeletions: whether the branch can be deleted.
+	// +optional
+	AllowDeletions bool `json:"allowDeletions,omitempty"`
+
+	// RequiredConversationResolution: whether all the conversations on
+	// the code must be resolved before merging.
+	// +optional
+	RequiredConversationResolution bool `json:"requiredConversationResolution,omitempty"`
+
+	// RequiredSignatures: whether commits must be signed.
+	// +optional
+	RequiredSignatures bool `json:"requiredSignatures,omitempty"`
+
+	// Restrictions: the users, teams and apps allowed to push to the
+	// branch. Only available for organization-owned repositories. If not
+	// set, anyone with write access can push.
+	// +optional
+	Restrictions *Actors `json:"restrictions,omitempty"`
+}
+
+// BranchProtectionStatus defines the observed state of BranchProtection
+type BranchProtectionStatus struct {
+	prv1.ConditionedStatus `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//+kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+
+// BranchProtection is the Schema for the branchprotections API
+type BranchProtection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BranchProtectionSpec   `json:"spec,omitempty"`
+	Status BranchProtectionStatus `json:"status,omitempty"`
+}
+
+// GetCondition of this BranchProtection.
+func (mg *BranchProtection) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// SetConditions of this BranchProtection.
+func (mg *BranchProtection) SetConditions(c ...prv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+//+kubebuilder:object:root=true
+
+// BranchProtectionList contains a list of BranchProtection
+type BranchProtectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BranchProtection `json:"items"`
+}
+
+// GetItems of this BranchProtectionList.
+func (l *BranchProtectionList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
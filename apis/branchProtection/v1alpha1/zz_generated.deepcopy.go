@@ -0,0 +1,238 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2023 Kiratech SPA.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Actors) DeepCopyInto(out *Actors) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Apps != nil {
+		in, out := &in.Apps, &out.Apps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Actors.
+func (in *Actors) DeepCopy() *Actors {
+	if in == nil {
+		return nil
+	}
+	out := new(Actors)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BranchProtection) DeepCopyInto(out *BranchProtection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BranchProtection.
+func (in *BranchProtection) DeepCopy() *BranchProtection {
+	if in == nil {
+		return nil
+	}
+	out := new(BranchProtection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BranchProtection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BranchProtectionList) DeepCopyInto(out *BranchProtectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BranchProtection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BranchProtectionList.
+func (in *BranchProtectionList) DeepCopy() *BranchProtectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(BranchProtectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BranchProtectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BranchProtectionSpec) DeepCopyInto(out *BranchProtectionSpec) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(v1.CredentialSelectors)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verbose != nil {
+		in, out := &in.Verbose, &out.Verbose
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequiredStatusChecks != nil {
+		in, out := &in.RequiredStatusChecks, &out.RequiredStatusChecks
+		*out = new(RequiredStatusChecks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequiredPullRequestReviews != nil {
+		in, out := &in.RequiredPullRequestReviews, &out.RequiredPullRequestReviews
+		*out = new(RequiredPullRequestReviews)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restrictions != nil {
+		in, out := &in.Restrictions, &out.Restrictions
+		*out = new(Actors)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BranchProtectionSpec.
+func (in *BranchProtectionSpec) DeepCopy() *BranchProtectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BranchProtectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BranchProtectionStatus) DeepCopyInto(out *BranchProtectionStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BranchProtectionStatus.
+func (in *BranchProtectionStatus) DeepCopy() *BranchProtectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BranchProtectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequiredPullRequestReviews) DeepCopyInto(out *RequiredPullRequestReviews) {
+	*out = *in
+	if in.DismissalRestrictions != nil {
+		in, out := &in.DismissalRestrictions, &out.DismissalRestrictions
+		*out = new(Actors)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BypassPullRequestAllowances != nil {
+		in, out := &in.BypassPullRequestAllowances, &out.BypassPullRequestAllowances
+		*out = new(Actors)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequiredPullRequestReviews.
+func (in *RequiredPullRequestReviews) DeepCopy() *RequiredPullRequestReviews {
+	if in == nil {
+		return nil
+	}
+	out := new(RequiredPullRequestReviews)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequiredStatusChecks) DeepCopyInto(out *RequiredStatusChecks) {
+	*out = *in
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]StatusCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequiredStatusChecks.
+func (in *RequiredStatusChecks) DeepCopy() *RequiredStatusChecks {
+	if in == nil {
+		return nil
+	}
+	out := new(RequiredStatusChecks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusCheck) DeepCopyInto(out *StatusCheck) {
+	*out = *in
+	if in.AppId != nil {
+		in, out := &in.AppId, &out.AppId
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusCheck.
+func (in *StatusCheck) DeepCopy() *StatusCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusCheck)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -32,7 +32,7 @@ type CollaboratorSpec struct {
 	// +immutable
 	Username string `json:"username"`
 
-	// Permission: The permission to grant the collaborator. Only valid on organization-owned repositories. We accept the following permissions to be set: pull, triage, push, maintain, admin and you can also specify a custom repository role name, if the owning organization has defined any. Default: push
+	// Permission: The permission to grant the collaborator. Only valid on organization-owned repositories: on user-owned repositories the permission is always push. We accept the following permissions to be set: pull, triage, push, maintain, admin and you can also specify a custom repository role name, if the owning organization has defined any. Default: push
 	// +immutable
 	Permission string `json:"permission"`
 }
@@ -43,8 +43,25 @@ type CollaboratorStatus struct {
 
 	// Permission: The permission granted to the collaborator.
 	Permission *string `json:"permission,omitempty"`
+
+	// InvitationId: the numeric identifier of the pending invitation, until
+	// the user accepts it and becomes a collaborator.
+	InvitationId *int64 `json:"invitationId,omitempty"`
+
+	// InvitationExpiresAt: when the pending invitation expires.
+	InvitationExpiresAt *metav1.Time `json:"invitationExpiresAt,omitempty"`
+
+	// OwnerType: the type of the owner of the repository, Organization or
+	// User. It decides the permission to grant, so it is resolved once.
+	OwnerType *string `json:"ownerType,omitempty"`
 }
 
+// Types of the owner of a repository.
+const (
+	OwnerTypeOrganization = "Organization"
+	OwnerTypeUser         = "User"
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
@@ -0,0 +1,22 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReasonInvitationPending reports that the user has not accepted the
+// invitation to collaborate on the repository yet.
+const ReasonInvitationPending prv1.ConditionReason = "InvitationPending"
+
+// InvitationPending returns a condition that indicates the user has been
+// invited but is not a collaborator yet.
+func InvitationPending() prv1.Condition {
+	return prv1.Condition{
+		Type:               prv1.TypeReady,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvitationPending,
+		Message:            "the user has not accepted the invitation to collaborate yet",
+	}
+}
@@ -118,6 +118,20 @@ func (in *CollaboratorStatus) DeepCopyInto(out *CollaboratorStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.InvitationId != nil {
+		in, out := &in.InvitationId, &out.InvitationId
+		*out = new(int64)
+		**out = **in
+	}
+	if in.InvitationExpiresAt != nil {
+		in, out := &in.InvitationExpiresAt, &out.InvitationExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.OwnerType != nil {
+		in, out := &in.OwnerType, &out.OwnerType
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollaboratorStatus.
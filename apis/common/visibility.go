@@ -0,0 +1,10 @@
+// Package common holds the definitions shared by the APIs of the provider.
+package common
+
+// Visibility values of organization secrets and variables: which
+// repositories of the organization can access them.
+const (
+	VisibilityAll      = "all"
+	VisibilityPrivate  = "private"
+	VisibilitySelected = "selected"
+)
@@ -0,0 +1,112 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Keys of the connection Secret a generated key pair is written to.
+const (
+	ConnectionKeyPrivateKey = "privateKey"
+	ConnectionKeyPublicKey  = "publicKey"
+)
+
+// DeployKeySpec defines the desired state of DeployKey
+type DeployKeySpec struct {
+	// ApiUrl: the baseUrl for the REST API provider.
+	// +optional
+	// +immutable
+	ApiUrl string `json:"apiUrl,omitempty"`
+
+	// Credentials required to authenticate ReST API git server.
+	Credentials *prv1.CredentialSelectors `json:"credentials"`
+
+	// Verbose is true dumps your client requests and responses.
+	// +optional
+	Verbose *bool `json:"verbose,omitempty"`
+
+	// Owner: the account owner of the repository. The name is not case sensitive.
+	// +immutable
+	Owner string `json:"owner"`
+
+	// Repo: the name of the repository without the .git extension. The name is not case sensitive.
+	// +immutable
+	Repo string `json:"repo"`
+
+	// Title: the name of the key.
+	Title string `json:"title"`
+
+	// ReadOnly: whether the key can only read the repository (default: true).
+	// +optional
+	ReadOnly *bool `json:"readOnly,omitempty"`
+
+	// PublicKeyRef: the key of the Secret holding the public key to register.
+	// When not set, an ed25519 key pair is generated and written to the
+	// writeConnectionSecretToRef Secret.
+	// +optional
+	PublicKeyRef *prv1.SecretKeySelector `json:"publicKeyRef,omitempty"`
+
+	// WriteConnectionSecretToRef: the Secret the generated key pair is
+	// written to, under the privateKey and publicKey keys. Removing the
+	// key pair from the Secret generates a new one.
+	// +optional
+	WriteConnectionSecretToRef *prv1.Reference `json:"writeConnectionSecretToRef,omitempty"`
+}
+
+// DeployKeyStatus defines the observed state of DeployKey
+type DeployKeyStatus struct {
+	prv1.ConditionedStatus `json:",inline"`
+
+	// Id: the numeric identifier of the key. Deploy keys cannot be edited,
+	// so the id changes whenever the key is replaced.
+	Id *int64 `json:"id,omitempty"`
+
+	// Verified: whether the key has been verified.
+	Verified *bool `json:"verified,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//+kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+//+kubebuilder:printcolumn:name="TITLE",type="string",JSONPath=".spec.title"
+//+kubebuilder:printcolumn:name="ID",type="integer",JSONPath=".status.id",priority=1
+
+// DeployKey is the Schema for the deploykeys API
+type DeployKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DeployKeySpec   `json:"spec,omitempty"`
+	Status DeployKeyStatus `json:"status,omitempty"`
+}
+
+// GetCondition of this DeployKey.
+func (mg *DeployKey) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// SetConditions of this DeployKey.
+func (mg *DeployKey) SetConditions(c ...prv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+//+kubebuilder:object:root=true
+
+// DeployKeyList contains a list of DeployKey
+type DeployKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeployKey `json:"items"`
+}
+
+// GetItems of this DeployKeyList.
+func (l *DeployKeyList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
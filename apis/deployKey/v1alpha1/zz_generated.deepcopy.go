@@ -0,0 +1,151 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2023 Kiratech SPA.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeployKey) DeepCopyInto(out *DeployKey) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeployKey.
+func (in *DeployKey) DeepCopy() *DeployKey {
+	if in == nil {
+		return nil
+	}
+	out := new(DeployKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeployKey) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeployKeyList) DeepCopyInto(out *DeployKeyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DeployKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeployKeyList.
+func (in *DeployKeyList) DeepCopy() *DeployKeyList {
+	if in == nil {
+		return nil
+	}
+	out := new(DeployKeyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeployKeyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeployKeySpec) DeepCopyInto(out *DeployKeySpec) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(v1.CredentialSelectors)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verbose != nil {
+		in, out := &in.Verbose, &out.Verbose
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadOnly != nil {
+		in, out := &in.ReadOnly, &out.ReadOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PublicKeyRef != nil {
+		in, out := &in.PublicKeyRef, &out.PublicKeyRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.WriteConnectionSecretToRef != nil {
+		in, out := &in.WriteConnectionSecretToRef, &out.WriteConnectionSecretToRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeployKeySpec.
+func (in *DeployKeySpec) DeepCopy() *DeployKeySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeployKeySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeployKeyStatus) DeepCopyInto(out *DeployKeyStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Id != nil {
+		in, out := &in.Id, &out.Id
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Verified != nil {
+		in, out := &in.Verified, &out.Verified
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeployKeyStatus.
+func (in *DeployKeyStatus) DeepCopy() *DeployKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeployKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,150 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Deployment branch policies of an environment.
+const (
+	DeploymentBranchPolicyProtectedBranches    = "protectedBranches"
+	DeploymentBranchPolicyCustomBranchPolicies = "customBranchPolicies"
+)
+
+// Reviewer types.
+const (
+	ReviewerTypeUser = "User"
+	ReviewerTypeTeam = "Team"
+)
+
+// Reviewer is a user or a team that can approve the deployments to an environment.
+type Reviewer struct {
+	// Type: the type of the reviewer.
+	// +kubebuilder:validation:Enum=User;Team
+	Type string `json:"type"`
+
+	// Name: the login of the user or the slug of the team. Teams must
+	// belong to the owner of the repository.
+	Name string `json:"name"`
+}
+
+// BranchPolicy is a name pattern of the branches or tags that can deploy
+// to an environment.
+type BranchPolicy struct {
+	// Name: the name pattern, e.g. release/*.
+	Name string `json:"name"`
+
+	// Type: whether the pattern matches branches or tags (default: branch).
+	// +optional
+	// +kubebuilder:validation:Enum=branch;tag
+	Type *string `json:"type,omitempty"`
+}
+
+// EnvironmentSpec defines the desired state of Environment
+type EnvironmentSpec struct {
+	// ApiUrl: the baseUrl for the REST API provider.
+	// +optional
+	// +immutable
+	ApiUrl string `json:"apiUrl,omitempty"`
+
+	// Credentials required to authenticate ReST API git server.
+	Credentials *prv1.CredentialSelectors `json:"credentials"`
+
+	// Verbose is true dumps your client requests and responses.
+	// +optional
+	Verbose *bool `json:"verbose,omitempty"`
+
+	// Owner: the account owner of the repository. The name is not case sensitive.
+	// +immutable
+	Owner string `json:"owner"`
+
+	// Repo: the name of the repository without the .git extension. The name is not case sensitive.
+	// +immutable
+	Repo string `json:"repo"`
+
+	// Name: the name of the environment.
+	// +immutable
+	Name string `json:"name"`
+
+	// WaitTimer: the minutes to wait before allowing deployments to proceed.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=43200
+	WaitTimer *int `json:"waitTimer,omitempty"`
+
+	// Reviewers: the users or teams that can approve the deployments. At
+	// least one of them must approve a deployment for it to proceed.
+	// +optional
+	// +kubebuilder:validation:MaxItems=6
+	Reviewers []Reviewer `json:"reviewers,omitempty"`
+
+	// PreventSelfReview: whether the user who triggered a deployment
+	// cannot approve it.
+	// +optional
+	PreventSelfReview *bool `json:"preventSelfReview,omitempty"`
+
+	// DeploymentBranchPolicy: which branches can deploy to the environment,
+	// either the protected branches or the ones matching the branch
+	// policies. All branches can deploy when it is not set.
+	// +optional
+	// +kubebuilder:validation:Enum=protectedBranches;customBranchPolicies
+	DeploymentBranchPolicy *string `json:"deploymentBranchPolicy,omitempty"`
+
+	// BranchPolicies: the name patterns of the branches and tags that can
+	// deploy. Requires the customBranchPolicies deployment branch policy.
+	// +optional
+	BranchPolicies []BranchPolicy `json:"branchPolicies,omitempty"`
+}
+
+// EnvironmentStatus defines the observed state of Environment
+type EnvironmentStatus struct {
+	prv1.ConditionedStatus `json:",inline"`
+
+	// Id: the numeric identifier of the environment.
+	Id *int64 `json:"id,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//+kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+//+kubebuilder:printcolumn:name="ENVIRONMENT",type="string",JSONPath=".spec.name"
+
+// Environment is the Schema for the environments API
+type Environment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EnvironmentSpec   `json:"spec,omitempty"`
+	Status EnvironmentStatus `json:"status,omitempty"`
+}
+
+// GetCondition of this Environment.
+func (mg *Environment) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// SetConditions of this Environment.
+func (mg *Environment) SetConditions(c ...prv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+//+kubebuilder:object:root=true
+
+// EnvironmentList contains a list of Environment
+type EnvironmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Environment `json:"items"`
+}
+
+// GetItems of this EnvironmentList.
+func (l *EnvironmentList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
@@ -6,6 +6,15 @@ import (
 	repov1alpha1 "github.com/krateoplatformops/github-provider/apis/repo/v1alpha1"
 	collaboratorv1alpha1 "github.com/krateoplatformops/github-provider/apis/collaborator/v1alpha1"
 	teamRepov1alpha1 "github.com/krateoplatformops/github-provider/apis/teamRepo/v1alpha1"
+	branchProtectionv1alpha1 "github.com/krateoplatformops/github-provider/apis/branchProtection/v1alpha1"
+	rulesetv1alpha1 "github.com/krateoplatformops/github-provider/apis/ruleset/v1alpha1"
+	webhookv1alpha1 "github.com/krateoplatformops/github-provider/apis/webhook/v1alpha1"
+	deployKeyv1alpha1 "github.com/krateoplatformops/github-provider/apis/deployKey/v1alpha1"
+	secretv1alpha1 "github.com/krateoplatformops/github-provider/apis/secret/v1alpha1"
+	variablev1alpha1 "github.com/krateoplatformops/github-provider/apis/variable/v1alpha1"
+	environmentv1alpha1 "github.com/krateoplatformops/github-provider/apis/environment/v1alpha1"
+	teamv1alpha1 "github.com/krateoplatformops/github-provider/apis/team/v1alpha1"
+	orgMembershipv1alpha1 "github.com/krateoplatformops/github-provider/apis/orgMembership/v1alpha1"
 )
 
 func init() {
@@ -14,6 +23,15 @@ func init() {
 		repov1alpha1.SchemeBuilder.AddToScheme,
 		collaboratorv1alpha1.SchemeBuilder.AddToScheme,
 		teamRepov1alpha1.SchemeBuilder.AddToScheme,
+		branchProtectionv1alpha1.SchemeBuilder.AddToScheme,
+		rulesetv1alpha1.SchemeBuilder.AddToScheme,
+		webhookv1alpha1.SchemeBuilder.AddToScheme,
+		deployKeyv1alpha1.SchemeBuilder.AddToScheme,
+		secretv1alpha1.SchemeBuilder.AddToScheme,
+		variablev1alpha1.SchemeBuilder.AddToScheme,
+		environmentv1alpha1.SchemeBuilder.AddToScheme,
+		teamv1alpha1.SchemeBuilder.AddToScheme,
+		orgMembershipv1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
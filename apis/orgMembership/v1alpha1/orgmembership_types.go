@@ -0,0 +1,142 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Roles of an organization member.
+const (
+	RoleAdmin          = "admin"
+	RoleMember         = "member"
+	RoleBillingManager = "billing_manager"
+)
+
+// States of an organization membership.
+const (
+	StateActive  = "active"
+	StatePending = "pending"
+	StateExpired = "expired"
+	StateFailed  = "failed"
+)
+
+// OrgMembershipSpec defines the desired state of OrgMembership. Set either
+// username or email.
+type OrgMembershipSpec struct {
+	// ApiUrl: the baseUrl for the REST API provider.
+	// +optional
+	// +immutable
+	ApiUrl string `json:"apiUrl,omitempty"`
+
+	// Credentials required to authenticate ReST API git server.
+	Credentials *prv1.CredentialSelectors `json:"credentials"`
+
+	// Verbose is true dumps your client requests and responses.
+	// +optional
+	Verbose *bool `json:"verbose,omitempty"`
+
+	// Org: the organization name.
+	// +immutable
+	Org string `json:"org"`
+
+	// Username: the login of the user.
+	// +optional
+	// +immutable
+	Username *string `json:"username,omitempty"`
+
+	// Email: the email address to send the invitation to, for people who
+	// may not have a GitHub account yet. Once the invitation is accepted
+	// the membership can no longer be changed through this resource.
+	// +optional
+	// +immutable
+	Email *string `json:"email,omitempty"`
+
+	// Role: the role of the user in the organization (default: member).
+	// The role of billing managers can only be set by the invitation.
+	// +optional
+	// +kubebuilder:validation:Enum=admin;member;billing_manager
+	Role *string `json:"role,omitempty"`
+
+	// TeamIds: the ids of the teams the user joins when accepting the
+	// invitation.
+	// +optional
+	TeamIds []int64 `json:"teamIds,omitempty"`
+
+	// DeletionMode: what happens when the resource is deleted: cancel
+	// only cancels a pending invitation and leaves an active member in the
+	// organization, remove also removes the member (default: cancel). It
+	// only applies when the krateo.io/deletion-policy annotation allows
+	// deleting the external resource.
+	// +optional
+	// +kubebuilder:validation:Enum=cancel;remove
+	DeletionMode *string `json:"deletionMode,omitempty"`
+}
+
+const (
+	// DeletionModeCancel cancels a pending invitation only.
+	DeletionModeCancel = "cancel"
+	// DeletionModeRemove also removes an active member from the organization.
+	DeletionModeRemove = "remove"
+)
+
+// OrgMembershipStatus defines the observed state of OrgMembership
+type OrgMembershipStatus struct {
+	prv1.ConditionedStatus `json:",inline"`
+
+	// State: the state of the membership: pending until the invitation is
+	// accepted, then active. Expired invitations, and invitations that
+	// failed otherwise, e.g. were cancelled, are sent again.
+	State *string `json:"state,omitempty"`
+
+	// InvitationId: the numeric identifier of the last invitation.
+	InvitationId *int64 `json:"invitationId,omitempty"`
+
+	// Role: the observed role of the user in the organization.
+	Role *string `json:"role,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//+kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+//+kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.org"
+//+kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state"
+
+// OrgMembership is the Schema for the orgmemberships API
+type OrgMembership struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrgMembershipSpec   `json:"spec,omitempty"`
+	Status OrgMembershipStatus `json:"status,omitempty"`
+}
+
+// GetCondition of this OrgMembership.
+func (mg *OrgMembership) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// SetConditions of this OrgMembership.
+func (mg *OrgMembership) SetConditions(c ...prv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+//+kubebuilder:object:root=true
+
+// OrgMembershipList contains a list of OrgMembership
+type OrgMembershipList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrgMembership `json:"items"`
+}
+
+// GetItems of this OrgMembershipList.
+func (l *OrgMembershipList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
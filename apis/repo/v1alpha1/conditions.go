@@ -0,0 +1,40 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TypeTransfer reports whether a repository transfer is in progress.
+const TypeTransfer prv1.ConditionType = "Transfer"
+
+// Reasons a repository transfer is or is not in progress.
+const (
+	ReasonTransferPending  prv1.ConditionReason = "TransferPending"
+	ReasonTransferComplete prv1.ConditionReason = "TransferComplete"
+)
+
+// TransferPending returns a condition that indicates the repository is
+// being transferred to a new owner.
+func TransferPending(owner string) prv1.Condition {
+	return prv1.Condition{
+		Type:               TypeTransfer,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTransferPending,
+		Message:            fmt.Sprintf("waiting for the transfer to '%s' to complete", owner),
+	}
+}
+
+// TransferComplete returns a condition that indicates the repository
+// has been transferred to its new owner.
+func TransferComplete() prv1.Condition {
+	return prv1.Condition{
+		Type:               TypeTransfer,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTransferComplete,
+	}
+}
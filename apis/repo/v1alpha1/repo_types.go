@@ -6,6 +6,64 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// TemplateRef identifies the template repository a repository is generated from.
+type TemplateRef struct {
+	// Owner: the organization or user that owns the template repository.
+	Owner string `json:"owner"`
+
+	// Name: the name of the template repository.
+	Name string `json:"name"`
+
+	// IncludeAllBranches: whether all the branches of the template are
+	// copied, instead of just the default branch (default: false).
+	// +optional
+	IncludeAllBranches *bool `json:"includeAllBranches,omitempty"`
+}
+
+// ForkSource identifies the upstream repository a repository is forked from.
+type ForkSource struct {
+	// Owner: the organization or user that owns the upstream repository.
+	Owner string `json:"owner"`
+
+	// Repo: the name of the upstream repository. The fork is named after
+	// the name field, so it can differ from the upstream name.
+	Repo string `json:"repo"`
+
+	// DefaultBranchOnly: whether only the default branch of the upstream
+	// repository is forked (default: false).
+	// +optional
+	DefaultBranchOnly *bool `json:"defaultBranchOnly,omitempty"`
+}
+
+// SecurityAndAnalysis defines the security and analysis features of a
+// repository. Features that are not declared are left untouched.
+type SecurityAndAnalysis struct {
+	// AdvancedSecurity: whether GitHub Advanced Security is enabled.
+	// +optional
+	AdvancedSecurity *bool `json:"advancedSecurity,omitempty"`
+
+	// SecretScanning: whether secret scanning is enabled.
+	// +optional
+	SecretScanning *bool `json:"secretScanning,omitempty"`
+
+	// SecretScanningPushProtection: whether secret scanning push protection is enabled.
+	// +optional
+	SecretScanningPushProtection *bool `json:"secretScanningPushProtection,omitempty"`
+
+	// SecretScanningValidityChecks: whether secret scanning validity checks are enabled.
+	// +optional
+	SecretScanningValidityChecks *bool `json:"secretScanningValidityChecks,omitempty"`
+
+	// VulnerabilityAlerts: whether Dependabot alerts are enabled.
+	// +optional
+	VulnerabilityAlerts *bool `json:"vulnerabilityAlerts,omitempty"`
+
+	// AutomatedSecurityFixes: whether Dependabot security updates are
+	// enabled. Requires vulnerability alerts.
+	// +optional
+	AutomatedSecurityFixes *bool `json:"automatedSecurityFixes,omitempty"`
+}
+
 // RepoSpec defines the desired state of Repo
 type RepoSpec struct {
 	// ApiUrl: the baseUrl for the REST API provider.
@@ -20,23 +78,167 @@ type RepoSpec struct {
 	// +optional
 	Verbose *bool `json:"verbose,omitempty"`
 
-	// Org: the organization name.
-	// +immutable
+	// Org: the organization name. Once the repository is tracked by its
+	// id, changing it transfers the repository to the new owner.
 	Org string `json:"org"`
 
-	// Name: the name of the repository.
-	// +immutable
+	// Name: the name of the repository. Once the repository is tracked by
+	// its id, changing it renames the repository.
 	Name string `json:"name"`
 
 	// Private: whether the repository is private (default: true).
+	// Ignored when visibility is set.
 	// +optional
 	Private bool `json:"private,omitempty"`
 
+	// Visibility: the visibility of the repository. The internal value is
+	// only available for organizations that are part of an enterprise.
+	// Takes precedence over private.
+	// +optional
+	// +kubebuilder:validation:Enum=public;private;internal
+	Visibility *string `json:"visibility,omitempty"`
+
 	// Initialize: whether the repository must be initialized (default: true).
+	// Ignored when templateRef is set.
 	// +optional
 	Initialize *bool `json:"initialize,omitempty"`
+
+	// TemplateRef: the template repository to generate the repository from.
+	// The other settings are applied once the repository has been generated.
+	// +optional
+	// +immutable
+	TemplateRef *TemplateRef `json:"templateRef,omitempty"`
+
+	// Fork: the upstream repository to fork. Forking is asynchronous, the
+	// repository becomes available once GitHub has finished copying it.
+	// The other settings are applied once the fork exists.
+	// Cannot be combined with templateRef.
+	// +optional
+	// +immutable
+	Fork *ForkSource `json:"fork,omitempty"`
+
+	// Description: a short description of the repository.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Homepage: a URL with more information about the repository.
+	// +optional
+	Homepage *string `json:"homepage,omitempty"`
+
+	// DefaultBranch: the name of the default branch of the repository.
+	// If a branch with this name exists it becomes the default branch,
+	// otherwise the current default branch is renamed (e.g. master to main).
+	// +optional
+	DefaultBranch *string `json:"defaultBranch,omitempty"`
+
+	// HasIssues: whether issues are enabled.
+	// +optional
+	HasIssues *bool `json:"hasIssues,omitempty"`
+
+	// HasProjects: whether projects are enabled.
+	// +optional
+	HasProjects *bool `json:"hasProjects,omitempty"`
+
+	// HasWiki: whether the wiki is enabled.
+	// +optional
+	HasWiki *bool `json:"hasWiki,omitempty"`
+
+	// HasDiscussions: whether discussions are enabled.
+	// +optional
+	HasDiscussions *bool `json:"hasDiscussions,omitempty"`
+
+	// GitignoreTemplate: the name of the .gitignore template to apply
+	// (e.g. Go). Only applied on creation.
+	// +optional
+	// +immutable
+	GitignoreTemplate *string `json:"gitignoreTemplate,omitempty"`
+
+	// LicenseTemplate: the keyword of the open source license to apply
+	// (e.g. mit). Only applied on creation.
+	// +optional
+	// +immutable
+	LicenseTemplate *string `json:"licenseTemplate,omitempty"`
+
+	// AllowSquashMerge: whether squash-merging pull requests is allowed.
+	// +optional
+	AllowSquashMerge *bool `json:"allowSquashMerge,omitempty"`
+
+	// AllowMergeCommit: whether merging pull requests with a merge commit is allowed.
+	// +optional
+	AllowMergeCommit *bool `json:"allowMergeCommit,omitempty"`
+
+	// AllowRebaseMerge: whether rebase-merging pull requests is allowed.
+	// +optional
+	AllowRebaseMerge *bool `json:"allowRebaseMerge,omitempty"`
+
+	// AllowAutoMerge: whether auto-merge can be enabled on pull requests.
+	// +optional
+	AllowAutoMerge *bool `json:"allowAutoMerge,omitempty"`
+
+	// DeleteBranchOnMerge: whether head branches are deleted automatically
+	// when pull requests are merged.
+	// +optional
+	DeleteBranchOnMerge *bool `json:"deleteBranchOnMerge,omitempty"`
+
+	// SquashMergeCommitTitle: the default title for squash merge commits.
+	// +optional
+	// +kubebuilder:validation:Enum=PR_TITLE;COMMIT_OR_PR_TITLE
+	SquashMergeCommitTitle *string `json:"squashMergeCommitTitle,omitempty"`
+
+	// SquashMergeCommitMessage: the default message for squash merge commits.
+	// +optional
+	// +kubebuilder:validation:Enum=PR_BODY;COMMIT_MESSAGES;BLANK
+	SquashMergeCommitMessage *string `json:"squashMergeCommitMessage,omitempty"`
+
+	// MergeCommitTitle: the default title for merge commits.
+	// +optional
+	// +kubebuilder:validation:Enum=PR_TITLE;MERGE_MESSAGE
+	MergeCommitTitle *string `json:"mergeCommitTitle,omitempty"`
+
+	// MergeCommitMessage: the default message for merge commits.
+	// +optional
+	// +kubebuilder:validation:Enum=PR_BODY;PR_TITLE;BLANK
+	MergeCommitMessage *string `json:"mergeCommitMessage,omitempty"`
+
+	// IsTemplate: whether the repository is available as a template repository.
+	// +optional
+	IsTemplate *bool `json:"isTemplate,omitempty"`
+
+	// Topics: the complete list of topics of the repository. Topics not
+	// in the list are removed, an empty list removes all the topics.
+	// Topics must start with a lowercase letter or a number, contain only
+	// lowercase letters, numbers and hyphens, and be at most 50 characters.
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9][a-z0-9-]{0,49}$`
+	Topics []string `json:"topics"`
+
+	// SecurityAndAnalysis: the security and analysis features of the repository.
+	// +optional
+	SecurityAndAnalysis *SecurityAndAnalysis `json:"securityAndAnalysis,omitempty"`
+
+	// Archived: whether the repository is archived. An archived repository
+	// is read-only, so the other settings are only reconciled while it is
+	// not archived.
+	// +optional
+	Archived *bool `json:"archived,omitempty"`
+
+	// DeletionMode: what happens to the repository when the resource is
+	// deleted: delete removes the repository, archive archives it instead
+	// (default: delete). It only applies when the krateo.io/deletion-policy
+	// annotation allows deleting the external resource.
+	// +optional
+	// +kubebuilder:validation:Enum=delete;archive
+	DeletionMode *string `json:"deletionMode,omitempty"`
 }
 
+const (
+	// DeletionModeDelete deletes the repository on GitHub.
+	DeletionModeDelete = "delete"
+	// DeletionModeArchive archives the repository on GitHub.
+	DeletionModeArchive = "archive"
+)
+
 // RepoStatus defines the observed state of Repo
 type RepoStatus struct {
 	prv1.ConditionedStatus `json:",inline"`
@@ -46,6 +248,54 @@ type RepoStatus struct {
 
 	// Private: whether the repository is private.
 	Private *bool `json:"private,omitempty"`
+
+	// Id: the numeric identifier of the repository. The repository is
+	// tracked by this id (also stored in the krateo.io/external-name
+	// annotation) so that it can be renamed or transferred.
+	Id *int64 `json:"id,omitempty"`
+
+	// NodeId: the GraphQL node identifier of the repository.
+	NodeId *string `json:"nodeId,omitempty"`
+
+	// CloneUrl: the HTTPS clone URL.
+	CloneUrl *string `json:"cloneUrl,omitempty"`
+
+	// SshUrl: the SSH clone URL.
+	SshUrl *string `json:"sshUrl,omitempty"`
+
+	// DefaultBranch: the current default branch.
+	DefaultBranch *string `json:"defaultBranch,omitempty"`
+
+	// Visibility: the repository visibility (public, private or internal).
+	Visibility *string `json:"visibility,omitempty"`
+
+	// Archived: whether the repository is archived.
+	Archived *bool `json:"archived,omitempty"`
+
+	// CreatedAt: when the repository was created.
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+
+	// PushedAt: when the last push to the repository happened.
+	PushedAt *metav1.Time `json:"pushedAt,omitempty"`
+
+	// Size: the size of the repository in kilobytes.
+	Size *int64 `json:"size,omitempty"`
+
+	// Template: the full name of the template repository the repository
+	// was generated from.
+	Template *string `json:"template,omitempty"`
+
+	// Parent: the full name of the repository this repository was forked from.
+	Parent *string `json:"parent,omitempty"`
+
+	// Source: the full name of the root repository of the fork network.
+	Source *string `json:"source,omitempty"`
+
+	// Topics: the topics of the repository.
+	Topics []string `json:"topics,omitempty"`
+
+	// PendingTransfer: the owner the repository is being transferred to.
+	PendingTransfer *string `json:"pendingTransfer,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -53,6 +303,12 @@ type RepoStatus struct {
 //+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
 //+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 //+kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+//+kubebuilder:printcolumn:name="VISIBILITY",type="string",JSONPath=".status.visibility"
+//+kubebuilder:printcolumn:name="BRANCH",type="string",JSONPath=".status.defaultBranch"
+//+kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.url"
+//+kubebuilder:printcolumn:name="ID",type="integer",JSONPath=".status.id",priority=1
+//+kubebuilder:printcolumn:name="ARCHIVED",type="boolean",JSONPath=".status.archived",priority=1
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Repo is the Schema for the repoes API
 type Repo struct {
@@ -25,6 +25,26 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForkSource) DeepCopyInto(out *ForkSource) {
+	*out = *in
+	if in.DefaultBranchOnly != nil {
+		in, out := &in.DefaultBranchOnly, &out.DefaultBranchOnly
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForkSource.
+func (in *ForkSource) DeepCopy() *ForkSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ForkSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Repo) DeepCopyInto(out *Repo) {
 	*out = *in
@@ -97,11 +117,141 @@ func (in *RepoSpec) DeepCopyInto(out *RepoSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Visibility != nil {
+		in, out := &in.Visibility, &out.Visibility
+		*out = new(string)
+		**out = **in
+	}
 	if in.Initialize != nil {
 		in, out := &in.Initialize, &out.Initialize
 		*out = new(bool)
 		**out = **in
 	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(TemplateRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fork != nil {
+		in, out := &in.Fork, &out.Fork
+		*out = new(ForkSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Homepage != nil {
+		in, out := &in.Homepage, &out.Homepage
+		*out = new(string)
+		**out = **in
+	}
+	if in.DefaultBranch != nil {
+		in, out := &in.DefaultBranch, &out.DefaultBranch
+		*out = new(string)
+		**out = **in
+	}
+	if in.HasIssues != nil {
+		in, out := &in.HasIssues, &out.HasIssues
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HasProjects != nil {
+		in, out := &in.HasProjects, &out.HasProjects
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HasWiki != nil {
+		in, out := &in.HasWiki, &out.HasWiki
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HasDiscussions != nil {
+		in, out := &in.HasDiscussions, &out.HasDiscussions
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GitignoreTemplate != nil {
+		in, out := &in.GitignoreTemplate, &out.GitignoreTemplate
+		*out = new(string)
+		**out = **in
+	}
+	if in.LicenseTemplate != nil {
+		in, out := &in.LicenseTemplate, &out.LicenseTemplate
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllowSquashMerge != nil {
+		in, out := &in.AllowSquashMerge, &out.AllowSquashMerge
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowMergeCommit != nil {
+		in, out := &in.AllowMergeCommit, &out.AllowMergeCommit
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowRebaseMerge != nil {
+		in, out := &in.AllowRebaseMerge, &out.AllowRebaseMerge
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowAutoMerge != nil {
+		in, out := &in.AllowAutoMerge, &out.AllowAutoMerge
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DeleteBranchOnMerge != nil {
+		in, out := &in.DeleteBranchOnMerge, &out.DeleteBranchOnMerge
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SquashMergeCommitTitle != nil {
+		in, out := &in.SquashMergeCommitTitle, &out.SquashMergeCommitTitle
+		*out = new(string)
+		**out = **in
+	}
+	if in.SquashMergeCommitMessage != nil {
+		in, out := &in.SquashMergeCommitMessage, &out.SquashMergeCommitMessage
+		*out = new(string)
+		**out = **in
+	}
+	if in.MergeCommitTitle != nil {
+		in, out := &in.MergeCommitTitle, &out.MergeCommitTitle
+		*out = new(string)
+		**out = **in
+	}
+	if in.MergeCommitMessage != nil {
+		in, out := &in.MergeCommitMessage, &out.MergeCommitMessage
+		*out = new(string)
+		**out = **in
+	}
+	if in.IsTemplate != nil {
+		in, out := &in.IsTemplate, &out.IsTemplate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityAndAnalysis != nil {
+		in, out := &in.SecurityAndAnalysis, &out.SecurityAndAnalysis
+		*out = new(SecurityAndAnalysis)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Archived != nil {
+		in, out := &in.Archived, &out.Archived
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DeletionMode != nil {
+		in, out := &in.DeletionMode, &out.DeletionMode
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoSpec.
@@ -128,6 +278,79 @@ func (in *RepoStatus) DeepCopyInto(out *RepoStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Id != nil {
+		in, out := &in.Id, &out.Id
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NodeId != nil {
+		in, out := &in.NodeId, &out.NodeId
+		*out = new(string)
+		**out = **in
+	}
+	if in.CloneUrl != nil {
+		in, out := &in.CloneUrl, &out.CloneUrl
+		*out = new(string)
+		**out = **in
+	}
+	if in.SshUrl != nil {
+		in, out := &in.SshUrl, &out.SshUrl
+		*out = new(string)
+		**out = **in
+	}
+	if in.DefaultBranch != nil {
+		in, out := &in.DefaultBranch, &out.DefaultBranch
+		*out = new(string)
+		**out = **in
+	}
+	if in.Visibility != nil {
+		in, out := &in.Visibility, &out.Visibility
+		*out = new(string)
+		**out = **in
+	}
+	if in.Archived != nil {
+		in, out := &in.Archived, &out.Archived
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.PushedAt != nil {
+		in, out := &in.PushedAt, &out.PushedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(string)
+		**out = **in
+	}
+	if in.Parent != nil {
+		in, out := &in.Parent, &out.Parent
+		*out = new(string)
+		**out = **in
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(string)
+		**out = **in
+	}
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingTransfer != nil {
+		in, out := &in.PendingTransfer, &out.PendingTransfer
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoStatus.
@@ -139,3 +362,68 @@ func (in *RepoStatus) DeepCopy() *RepoStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityAndAnalysis) DeepCopyInto(out *SecurityAndAnalysis) {
+	*out = *in
+	if in.AdvancedSecurity != nil {
+		in, out := &in.AdvancedSecurity, &out.AdvancedSecurity
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecretScanning != nil {
+		in, out := &in.SecretScanning, &out.SecretScanning
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecretScanningPushProtection != nil {
+		in, out := &in.SecretScanningPushProtection, &out.SecretScanningPushProtection
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecretScanningValidityChecks != nil {
+		in, out := &in.SecretScanningValidityChecks, &out.SecretScanningValidityChecks
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VulnerabilityAlerts != nil {
+		in, out := &in.VulnerabilityAlerts, &out.VulnerabilityAlerts
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AutomatedSecurityFixes != nil {
+		in, out := &in.AutomatedSecurityFixes, &out.AutomatedSecurityFixes
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityAndAnalysis.
+func (in *SecurityAndAnalysis) DeepCopy() *SecurityAndAnalysis {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityAndAnalysis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateRef) DeepCopyInto(out *TemplateRef) {
+	*out = *in
+	if in.IncludeAllBranches != nil {
+		in, out := &in.IncludeAllBranches, &out.IncludeAllBranches
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateRef.
+func (in *TemplateRef) DeepCopy() *TemplateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateRef)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,143 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RepositoryNameCondition selects the repositories a ruleset applies to by name.
+type RepositoryNameCondition struct {
+	// Include: the repository names or patterns to include. Accepts ~ALL
+	// for all the repositories.
+	// +optional
+	Include []string `json:"include,omitempty"`
+
+	// Exclude: the repository names or patterns to exclude.
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+
+	// Protected: whether renaming the targeted repositories is prevented.
+	// +optional
+	Protected bool `json:"protected,omitempty"`
+}
+
+// RepositoryPropertyTarget matches the repositories with a property value.
+type RepositoryPropertyTarget struct {
+	// Name: the name of the repository property.
+	Name string `json:"name"`
+
+	// PropertyValues: the values to match.
+	PropertyValues []string `json:"propertyValues"`
+
+	// Source: the source of the property (default: custom).
+	// +optional
+	// +kubebuilder:validation:Enum=custom;system
+	Source *string `json:"source,omitempty"`
+}
+
+// RepositoryPropertyCondition selects the repositories a ruleset applies to
+// by their custom properties.
+type RepositoryPropertyCondition struct {
+	// Include: the repository properties to include.
+	// +optional
+	Include []RepositoryPropertyTarget `json:"include,omitempty"`
+
+	// Exclude: the repository properties to exclude.
+	// +optional
+	Exclude []RepositoryPropertyTarget `json:"exclude,omitempty"`
+}
+
+// OrganizationRulesetConditions selects the repositories and refs an
+// organization ruleset applies to. The repositories are selected either by
+// name or by property.
+type OrganizationRulesetConditions struct {
+	// RefName: the branches or tags the ruleset applies to. Not used by push rulesets.
+	// +optional
+	RefName *RefNameCondition `json:"refName,omitempty"`
+
+	// RepositoryName: the repositories the ruleset applies to, by name.
+	// +optional
+	RepositoryName *RepositoryNameCondition `json:"repositoryName,omitempty"`
+
+	// RepositoryProperty: the repositories the ruleset applies to, by property.
+	// +optional
+	RepositoryProperty *RepositoryPropertyCondition `json:"repositoryProperty,omitempty"`
+}
+
+// OrganizationRulesetSpec defines the desired state of OrganizationRuleset
+type OrganizationRulesetSpec struct {
+	// ApiUrl: the baseUrl for the REST API provider.
+	// +optional
+	// +immutable
+	ApiUrl string `json:"apiUrl,omitempty"`
+
+	// Credentials required to authenticate ReST API git server.
+	Credentials *prv1.CredentialSelectors `json:"credentials"`
+
+	// Verbose is true dumps your client requests and responses.
+	// +optional
+	Verbose *bool `json:"verbose,omitempty"`
+
+	// Org: the organization name. The name is not case sensitive.
+	// +immutable
+	Org string `json:"org"`
+
+	RulesetSpec `json:",inline"`
+
+	// Conditions: the repositories and refs the ruleset applies to.
+	// +optional
+	Conditions *OrganizationRulesetConditions `json:"conditions,omitempty"`
+}
+
+// OrganizationRulesetStatus defines the observed state of OrganizationRuleset
+type OrganizationRulesetStatus struct {
+	prv1.ConditionedStatus `json:",inline"`
+
+	// Id: the numeric identifier of the ruleset.
+	Id *int64 `json:"id,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//+kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+//+kubebuilder:printcolumn:name="ID",type="integer",JSONPath=".status.id"
+
+// OrganizationRuleset is the Schema for the organizationrulesets API
+type OrganizationRuleset struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrganizationRulesetSpec   `json:"spec,omitempty"`
+	Status OrganizationRulesetStatus `json:"status,omitempty"`
+}
+
+// GetCondition of this OrganizationRuleset.
+func (mg *OrganizationRuleset) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// SetConditions of this OrganizationRuleset.
+func (mg *OrganizationRuleset) SetConditions(c ...prv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+//+kubebuilder:object:root=true
+
+// OrganizationRulesetList contains a list of OrganizationRuleset
+type OrganizationRulesetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrganizationRuleset `json:"items"`
+}
+
+// GetItems of this OrganizationRulesetList.
+func (l *OrganizationRulesetList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
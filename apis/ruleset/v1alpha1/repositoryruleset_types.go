@@ -0,0 +1,95 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RepositoryRulesetConditions selects the refs a repository ruleset applies to.
+type RepositoryRulesetConditions struct {
+	// RefName: the branches or tags the ruleset applies to.
+	// +optional
+	RefName *RefNameCondition `json:"refName,omitempty"`
+}
+
+// RepositoryRulesetSpec defines the desired state of RepositoryRuleset
+type RepositoryRulesetSpec struct {
+	// ApiUrl: the baseUrl for the REST API provider.
+	// +optional
+	// +immutable
+	ApiUrl string `json:"apiUrl,omitempty"`
+
+	// Credentials required to authenticate ReST API git server.
+	Credentials *prv1.CredentialSelectors `json:"credentials"`
+
+	// Verbose is true dumps your client requests and responses.
+	// +optional
+	Verbose *bool `json:"verbose,omitempty"`
+
+	// Owner: the account owner of the repository. The name is not case sensitive.
+	// +immutable
+	Owner string `json:"owner"`
+
+	// Repo: the name of the repository without the .git extension. The name is not case sensitive.
+	// +immutable
+	Repo string `json:"repo"`
+
+	RulesetSpec `json:",inline"`
+
+	// Conditions: the refs the ruleset applies to.
+	// +optional
+	Conditions *RepositoryRulesetConditions `json:"conditions,omitempty"`
+}
+
+// RepositoryRulesetStatus defines the observed state of RepositoryRuleset
+type RepositoryRulesetStatus struct {
+	prv1.ConditionedStatus `json:",inline"`
+
+	// Id: the numeric identifier of the ruleset.
+	Id *int64 `json:"id,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//+kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+//+kubebuilder:printcolumn:name="ID",type="integer",JSONPath=".status.id"
+
+// RepositoryRuleset is the Schema for the repositoryrulesets API
+type RepositoryRuleset struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepositoryRulesetSpec   `json:"spec,omitempty"`
+	Status RepositoryRulesetStatus `json:"status,omitempty"`
+}
+
+// GetCondition of this RepositoryRuleset.
+func (mg *RepositoryRuleset) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// SetConditions of this RepositoryRuleset.
+func (mg *RepositoryRuleset) SetConditions(c ...prv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+//+kubebuilder:object:root=true
+
+// RepositoryRulesetList contains a list of RepositoryRuleset
+type RepositoryRulesetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RepositoryRuleset `json:"items"`
+}
+
+// GetItems of this RepositoryRulesetList.
+func (l *RepositoryRulesetList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
@@ -0,0 +1,215 @@
+package v1alpha1
+
+// BypassActor is an actor that can bypass a ruleset.
+type BypassActor struct {
+	// ActorId: the id of the actor. Not used for OrganizationAdmin and DeployKey.
+	// +optional
+	ActorId *int64 `json:"actorId,omitempty"`
+
+	// ActorType: the type of the actor.
+	// +kubebuilder:validation:Enum=Integration;OrganizationAdmin;RepositoryRole;Team;DeployKey
+	ActorType string `json:"actorType"`
+
+	// BypassMode: when the actor can bypass the ruleset (default: always).
+	// +optional
+	// +kubebuilder:validation:Enum=always;pull_request
+	BypassMode *string `json:"bypassMode,omitempty"`
+}
+
+// RefNameCondition selects the branches or tags a ruleset applies to.
+type RefNameCondition struct {
+	// Include: the ref names or patterns to include. Accepts ~DEFAULT_BRANCH
+	// for the default branch and ~ALL for all the branches.
+	// +optional
+	Include []string `json:"include,omitempty"`
+
+	// Exclude: the ref names or patterns to exclude.
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// PullRequestParameters are the parameters of the pull_request rule.
+type PullRequestParameters struct {
+	// RequiredApprovingReviewCount: the number of approving reviews required.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	RequiredApprovingReviewCount int `json:"requiredApprovingReviewCount"`
+
+	// DismissStaleReviewsOnPush: whether approving reviews are dismissed when new commits are pushed.
+	// +optional
+	DismissStaleReviewsOnPush bool `json:"dismissStaleReviewsOnPush,omitempty"`
+
+	// RequireCodeOwnerReview: whether an approving review from a code owner is required.
+	// +optional
+	RequireCodeOwnerReview bool `json:"requireCodeOwnerReview,omitempty"`
+
+	// RequireLastPushApproval: whether the most recent push must be
+	// approved by someone other than the person who pushed it.
+	// +optional
+	RequireLastPushApproval bool `json:"requireLastPushApproval,omitempty"`
+
+	// RequiredReviewThreadResolution: whether all the conversations on the
+	// code must be resolved before merging.
+	// +optional
+	RequiredReviewThreadResolution bool `json:"requiredReviewThreadResolution,omitempty"`
+
+	// AllowedMergeMethods: the merge methods allowed (merge, squash, rebase).
+	// +optional
+	AllowedMergeMethods []string `json:"allowedMergeMethods,omitempty"`
+}
+
+// RequiredStatusCheck is a status check that must pass.
+type RequiredStatusCheck struct {
+	// Context: the name of the required check.
+	Context string `json:"context"`
+
+	// IntegrationId: the id of the GitHub App that must provide the check.
+	// +optional
+	IntegrationId *int64 `json:"integrationId,omitempty"`
+}
+
+// RequiredStatusChecksParameters are the parameters of the required_status_checks rule.
+type RequiredStatusChecksParameters struct {
+	// RequiredStatusChecks: the status checks that must pass.
+	RequiredStatusChecks []RequiredStatusCheck `json:"requiredStatusChecks"`
+
+	// StrictRequiredStatusChecksPolicy: whether branches must be up to date before merging.
+	// +optional
+	StrictRequiredStatusChecksPolicy bool `json:"strictRequiredStatusChecksPolicy,omitempty"`
+
+	// DoNotEnforceOnCreate: whether the checks are skipped when a ref is created.
+	// +optional
+	DoNotEnforceOnCreate bool `json:"doNotEnforceOnCreate,omitempty"`
+}
+
+// PatternParameters are the parameters of the rules that match a pattern
+// (commit_message_pattern, commit_author_email_pattern,
+// committer_email_pattern, branch_name_pattern and tag_name_pattern).
+type PatternParameters struct {
+	// Name: how the rule appears to users.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Negate: whether the rule fails when the pattern matches.
+	// +optional
+	Negate bool `json:"negate,omitempty"`
+
+	// Operator: how the pattern is matched.
+	// +kubebuilder:validation:Enum=starts_with;ends_with;contains;regex
+	Operator string `json:"operator"`
+
+	// Pattern: the pattern to match.
+	Pattern string `json:"pattern"`
+}
+
+// RequiredDeploymentsParameters are the parameters of the required_deployments rule.
+type RequiredDeploymentsParameters struct {
+	// RequiredDeploymentEnvironments: the environments that must be
+	// successfully deployed to before merging.
+	RequiredDeploymentEnvironments []string `json:"requiredDeploymentEnvironments"`
+}
+
+// UpdateParameters are the parameters of the update rule.
+type UpdateParameters struct {
+	// UpdateAllowsFetchAndMerge: whether the branch can pull changes from
+	// its upstream repository.
+	// +optional
+	UpdateAllowsFetchAndMerge bool `json:"updateAllowsFetchAndMerge,omitempty"`
+}
+
+// FilePathRestrictionParameters are the parameters of the file_path_restriction rule.
+type FilePathRestrictionParameters struct {
+	// RestrictedFilePaths: the file paths that cannot be pushed.
+	RestrictedFilePaths []string `json:"restrictedFilePaths"`
+}
+
+// MaxFilePathLengthParameters are the parameters of the max_file_path_length rule.
+type MaxFilePathLengthParameters struct {
+	// MaxFilePathLength: the maximum number of characters of a file path.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=256
+	MaxFilePathLength int `json:"maxFilePathLength"`
+}
+
+// FileExtensionRestrictionParameters are the parameters of the file_extension_restriction rule.
+type FileExtensionRestrictionParameters struct {
+	// RestrictedFileExtensions: the file extensions that cannot be pushed.
+	RestrictedFileExtensions []string `json:"restrictedFileExtensions"`
+}
+
+// MaxFileSizeParameters are the parameters of the max_file_size rule.
+type MaxFileSizeParameters struct {
+	// MaxFileSize: the maximum file size in megabytes.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MaxFileSize int `json:"maxFileSize"`
+}
+
+// Rule is a rule of a ruleset. Only the parameters matching the rule type
+// are used.
+type Rule struct {
+	// Type: the type of the rule.
+	// +kubebuilder:validation:Enum=creation;update;deletion;required_linear_history;required_deployments;required_signatures;pull_request;required_status_checks;non_fast_forward;commit_message_pattern;commit_author_email_pattern;committer_email_pattern;branch_name_pattern;tag_name_pattern;file_path_restriction;max_file_path_length;file_extension_restriction;max_file_size
+	Type string `json:"type"`
+
+	// Update: the parameters of the update rule.
+	// +optional
+	Update *UpdateParameters `json:"update,omitempty"`
+
+	// RequiredDeployments: the parameters of the required_deployments rule.
+	// +optional
+	RequiredDeployments *RequiredDeploymentsParameters `json:"requiredDeployments,omitempty"`
+
+	// PullRequest: the parameters of the pull_request rule.
+	// +optional
+	PullRequest *PullRequestParameters `json:"pullRequest,omitempty"`
+
+	// RequiredStatusChecks: the parameters of the required_status_checks rule.
+	// +optional
+	RequiredStatusChecks *RequiredStatusChecksParameters `json:"requiredStatusChecks,omitempty"`
+
+	// Pattern: the parameters of the *_pattern rules.
+	// +optional
+	Pattern *PatternParameters `json:"pattern,omitempty"`
+
+	// FilePathRestriction: the parameters of the file_path_restriction rule.
+	// +optional
+	FilePathRestriction *FilePathRestrictionParameters `json:"filePathRestriction,omitempty"`
+
+	// MaxFilePathLength: the parameters of the max_file_path_length rule.
+	// +optional
+	MaxFilePathLength *MaxFilePathLengthParameters `json:"maxFilePathLength,omitempty"`
+
+	// FileExtensionRestriction: the parameters of the file_extension_restriction rule.
+	// +optional
+	FileExtensionRestriction *FileExtensionRestrictionParameters `json:"fileExtensionRestriction,omitempty"`
+
+	// MaxFileSize: the parameters of the max_file_size rule.
+	// +optional
+	MaxFileSize *MaxFileSizeParameters `json:"maxFileSize,omitempty"`
+}
+
+// RulesetSpec defines the desired state of a ruleset, regardless of the
+// level it is defined at.
+type RulesetSpec struct {
+	// Name: the name of the ruleset.
+	Name string `json:"name"`
+
+	// Target: the target of the ruleset (default: branch).
+	// +optional
+	// +kubebuilder:validation:Enum=branch;tag;push
+	Target *string `json:"target,omitempty"`
+
+	// Enforcement: the enforcement level of the ruleset. evaluate only
+	// reports the violations and is available to enterprise organizations.
+	// +kubebuilder:validation:Enum=active;evaluate;disabled
+	Enforcement string `json:"enforcement"`
+
+	// BypassActors: the actors that can bypass the ruleset.
+	// +optional
+	BypassActors []BypassActor `json:"bypassActors,omitempty"`
+
+	// Rules: the rules of the ruleset.
+	// +optional
+	Rules []Rule `json:"rules,omitempty"`
+}
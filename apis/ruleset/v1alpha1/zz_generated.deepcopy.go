@@ -0,0 +1,708 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2023 Kiratech SPA.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BypassActor) DeepCopyInto(out *BypassActor) {
+	*out = *in
+	if in.ActorId != nil {
+		in, out := &in.ActorId, &out.ActorId
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BypassMode != nil {
+		in, out := &in.BypassMode, &out.BypassMode
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BypassActor.
+func (in *BypassActor) DeepCopy() *BypassActor {
+	if in == nil {
+		return nil
+	}
+	out := new(BypassActor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileExtensionRestrictionParameters) DeepCopyInto(out *FileExtensionRestrictionParameters) {
+	*out = *in
+	if in.RestrictedFileExtensions != nil {
+		in, out := &in.RestrictedFileExtensions, &out.RestrictedFileExtensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileExtensionRestrictionParameters.
+func (in *FileExtensionRestrictionParameters) DeepCopy() *FileExtensionRestrictionParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(FileExtensionRestrictionParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilePathRestrictionParameters) DeepCopyInto(out *FilePathRestrictionParameters) {
+	*out = *in
+	if in.RestrictedFilePaths != nil {
+		in, out := &in.RestrictedFilePaths, &out.RestrictedFilePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilePathRestrictionParameters.
+func (in *FilePathRestrictionParameters) DeepCopy() *FilePathRestrictionParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(FilePathRestrictionParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxFilePathLengthParameters) DeepCopyInto(out *MaxFilePathLengthParameters) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxFilePathLengthParameters.
+func (in *MaxFilePathLengthParameters) DeepCopy() *MaxFilePathLengthParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxFilePathLengthParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxFileSizeParameters) DeepCopyInto(out *MaxFileSizeParameters) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxFileSizeParameters.
+func (in *MaxFileSizeParameters) DeepCopy() *MaxFileSizeParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxFileSizeParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationRuleset) DeepCopyInto(out *OrganizationRuleset) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationRuleset.
+func (in *OrganizationRuleset) DeepCopy() *OrganizationRuleset {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationRuleset)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationRuleset) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationRulesetConditions) DeepCopyInto(out *OrganizationRulesetConditions) {
+	*out = *in
+	if in.RefName != nil {
+		in, out := &in.RefName, &out.RefName
+		*out = new(RefNameCondition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RepositoryName != nil {
+		in, out := &in.RepositoryName, &out.RepositoryName
+		*out = new(RepositoryNameCondition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RepositoryProperty != nil {
+		in, out := &in.RepositoryProperty, &out.RepositoryProperty
+		*out = new(RepositoryPropertyCondition)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationRulesetConditions.
+func (in *OrganizationRulesetConditions) DeepCopy() *OrganizationRulesetConditions {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationRulesetConditions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationRulesetList) DeepCopyInto(out *OrganizationRulesetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OrganizationRuleset, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationRulesetList.
+func (in *OrganizationRulesetList) DeepCopy() *OrganizationRulesetList {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationRulesetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationRulesetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationRulesetSpec) DeepCopyInto(out *OrganizationRulesetSpec) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(v1.CredentialSelectors)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verbose != nil {
+		in, out := &in.Verbose, &out.Verbose
+		*out = new(bool)
+		**out = **in
+	}
+	in.RulesetSpec.DeepCopyInto(&out.RulesetSpec)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = new(OrganizationRulesetConditions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationRulesetSpec.
+func (in *OrganizationRulesetSpec) DeepCopy() *OrganizationRulesetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationRulesetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationRulesetStatus) DeepCopyInto(out *OrganizationRulesetStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Id != nil {
+		in, out := &in.Id, &out.Id
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationRulesetStatus.
+func (in *OrganizationRulesetStatus) DeepCopy() *OrganizationRulesetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationRulesetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatternParameters) DeepCopyInto(out *PatternParameters) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatternParameters.
+func (in *PatternParameters) DeepCopy() *PatternParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(PatternParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullRequestParameters) DeepCopyInto(out *PullRequestParameters) {
+	*out = *in
+	if in.AllowedMergeMethods != nil {
+		in, out := &in.AllowedMergeMethods, &out.AllowedMergeMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestParameters.
+func (in *PullRequestParameters) DeepCopy() *PullRequestParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(PullRequestParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RefNameCondition) DeepCopyInto(out *RefNameCondition) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RefNameCondition.
+func (in *RefNameCondition) DeepCopy() *RefNameCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(RefNameCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryNameCondition) DeepCopyInto(out *RepositoryNameCondition) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryNameCondition.
+func (in *RepositoryNameCondition) DeepCopy() *RepositoryNameCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryNameCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryPropertyCondition) DeepCopyInto(out *RepositoryPropertyCondition) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]RepositoryPropertyTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]RepositoryPropertyTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryPropertyCondition.
+func (in *RepositoryPropertyCondition) DeepCopy() *RepositoryPropertyCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryPropertyCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryPropertyTarget) DeepCopyInto(out *RepositoryPropertyTarget) {
+	*out = *in
+	if in.PropertyValues != nil {
+		in, out := &in.PropertyValues, &out.PropertyValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryPropertyTarget.
+func (in *RepositoryPropertyTarget) DeepCopy() *RepositoryPropertyTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryPropertyTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryRuleset) DeepCopyInto(out *RepositoryRuleset) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryRuleset.
+func (in *RepositoryRuleset) DeepCopy() *RepositoryRuleset {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryRuleset)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RepositoryRuleset) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryRulesetConditions) DeepCopyInto(out *RepositoryRulesetConditions) {
+	*out = *in
+	if in.RefName != nil {
+		in, out := &in.RefName, &out.RefName
+		*out = new(RefNameCondition)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryRulesetConditions.
+func (in *RepositoryRulesetConditions) DeepCopy() *RepositoryRulesetConditions {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryRulesetConditions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryRulesetList) DeepCopyInto(out *RepositoryRulesetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RepositoryRuleset, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryRulesetList.
+func (in *RepositoryRulesetList) DeepCopy() *RepositoryRulesetList {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryRulesetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RepositoryRulesetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryRulesetSpec) DeepCopyInto(out *RepositoryRulesetSpec) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(v1.CredentialSelectors)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verbose != nil {
+		in, out := &in.Verbose, &out.Verbose
+		*out = new(bool)
+		**out = **in
+	}
+	in.RulesetSpec.DeepCopyInto(&out.RulesetSpec)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = new(RepositoryRulesetConditions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryRulesetSpec.
+func (in *RepositoryRulesetSpec) DeepCopy() *RepositoryRulesetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryRulesetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryRulesetStatus) DeepCopyInto(out *RepositoryRulesetStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Id != nil {
+		in, out := &in.Id, &out.Id
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryRulesetStatus.
+func (in *RepositoryRulesetStatus) DeepCopy() *RepositoryRulesetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryRulesetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequiredDeploymentsParameters) DeepCopyInto(out *RequiredDeploymentsParameters) {
+	*out = *in
+	if in.RequiredDeploymentEnvironments != nil {
+		in, out := &in.RequiredDeploymentEnvironments, &out.RequiredDeploymentEnvironments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequiredDeploymentsParameters.
+func (in *RequiredDeploymentsParameters) DeepCopy() *RequiredDeploymentsParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RequiredDeploymentsParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequiredStatusCheck) DeepCopyInto(out *RequiredStatusCheck) {
+	*out = *in
+	if in.IntegrationId != nil {
+		in, out := &in.IntegrationId, &out.IntegrationId
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequiredStatusCheck.
+func (in *RequiredStatusCheck) DeepCopy() *RequiredStatusCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(RequiredStatusCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequiredStatusChecksParameters) DeepCopyInto(out *RequiredStatusChecksParameters) {
+	*out = *in
+	if in.RequiredStatusChecks != nil {
+		in, out := &in.RequiredStatusChecks, &out.RequiredStatusChecks
+		*out = make([]RequiredStatusCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequiredStatusChecksParameters.
+func (in *RequiredStatusChecksParameters) DeepCopy() *RequiredStatusChecksParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RequiredStatusChecksParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Rule) DeepCopyInto(out *Rule) {
+	*out = *in
+	if in.Update != nil {
+		in, out := &in.Update, &out.Update
+		*out = new(UpdateParameters)
+		**out = **in
+	}
+	if in.RequiredDeployments != nil {
+		in, out := &in.RequiredDeployments, &out.RequiredDeployments
+		*out = new(RequiredDeploymentsParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(PullRequestParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequiredStatusChecks != nil {
+		in, out := &in.RequiredStatusChecks, &out.RequiredStatusChecks
+		*out = new(RequiredStatusChecksParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pattern != nil {
+		in, out := &in.Pattern, &out.Pattern
+		*out = new(PatternParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FilePathRestriction != nil {
+		in, out := &in.FilePathRestriction, &out.FilePathRestriction
+		*out = new(FilePathRestrictionParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxFilePathLength != nil {
+		in, out := &in.MaxFilePathLength, &out.MaxFilePathLength
+		*out = new(MaxFilePathLengthParameters)
+		**out = **in
+	}
+	if in.FileExtensionRestriction != nil {
+		in, out := &in.FileExtensionRestriction, &out.FileExtensionRestriction
+		*out = new(FileExtensionRestrictionParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxFileSize != nil {
+		in, out := &in.MaxFileSize, &out.MaxFileSize
+		*out = new(MaxFileSizeParameters)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rule.
+func (in *Rule) DeepCopy() *Rule {
+	if in == nil {
+		return nil
+	}
+	out := new(Rule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RulesetSpec) DeepCopyInto(out *RulesetSpec) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(string)
+		**out = **in
+	}
+	if in.BypassActors != nil {
+		in, out := &in.BypassActors, &out.BypassActors
+		*out = make([]BypassActor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]Rule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RulesetSpec.
+func (in *RulesetSpec) DeepCopy() *RulesetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RulesetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateParameters) DeepCopyInto(out *UpdateParameters) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateParameters.
+func (in *UpdateParameters) DeepCopy() *UpdateParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateParameters)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,85 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ActionsSecretSpec defines the desired state of ActionsSecret
+type ActionsSecretSpec struct {
+	// ApiUrl: the baseUrl for the REST API provider.
+	// +optional
+	// +immutable
+	ApiUrl string `json:"apiUrl,omitempty"`
+
+	// Credentials required to authenticate ReST API git server.
+	Credentials *prv1.CredentialSelectors `json:"credentials"`
+
+	// Verbose is true dumps your client requests and responses.
+	// +optional
+	Verbose *bool `json:"verbose,omitempty"`
+
+	SecretSpec `json:",inline"`
+
+	// Environment: the name of the repository environment the secret
+	// belongs to. Requires owner and repo.
+	// +optional
+	// +immutable
+	Environment string `json:"environment,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//+kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+//+kubebuilder:printcolumn:name="SECRET",type="string",JSONPath=".spec.name"
+//+kubebuilder:printcolumn:name="UPDATED",type="date",JSONPath=".status.updatedAt"
+
+// ActionsSecret is the Schema for the actionssecrets API
+type ActionsSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ActionsSecretSpec `json:"spec,omitempty"`
+	Status SecretStatus      `json:"status,omitempty"`
+}
+
+// GetCondition of this ActionsSecret.
+func (mg *ActionsSecret) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// SetConditions of this ActionsSecret.
+func (mg *ActionsSecret) SetConditions(c ...prv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// GetSecretSpec of this ActionsSecret.
+func (mg *ActionsSecret) GetSecretSpec() *SecretSpec {
+	return &mg.Spec.SecretSpec
+}
+
+// GetSecretStatus of this ActionsSecret.
+func (mg *ActionsSecret) GetSecretStatus() *SecretStatus {
+	return &mg.Status
+}
+
+//+kubebuilder:object:root=true
+
+// ActionsSecretList contains a list of ActionsSecret
+type ActionsSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ActionsSecret `json:"items"`
+}
+
+// GetItems of this ActionsSecretList.
+func (l *ActionsSecretList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
@@ -0,0 +1,79 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CodespacesSecretSpec defines the desired state of CodespacesSecret
+type CodespacesSecretSpec struct {
+	// ApiUrl: the baseUrl for the REST API provider.
+	// +optional
+	// +immutable
+	ApiUrl string `json:"apiUrl,omitempty"`
+
+	// Credentials required to authenticate ReST API git server.
+	Credentials *prv1.CredentialSelectors `json:"credentials"`
+
+	// Verbose is true dumps your client requests and responses.
+	// +optional
+	Verbose *bool `json:"verbose,omitempty"`
+
+	SecretSpec `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//+kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+//+kubebuilder:printcolumn:name="SECRET",type="string",JSONPath=".spec.name"
+//+kubebuilder:printcolumn:name="UPDATED",type="date",JSONPath=".status.updatedAt"
+
+// CodespacesSecret is the Schema for the codespacessecrets API
+type CodespacesSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CodespacesSecretSpec `json:"spec,omitempty"`
+	Status SecretStatus         `json:"status,omitempty"`
+}
+
+// GetCondition of this CodespacesSecret.
+func (mg *CodespacesSecret) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// SetConditions of this CodespacesSecret.
+func (mg *CodespacesSecret) SetConditions(c ...prv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// GetSecretSpec of this CodespacesSecret.
+func (mg *CodespacesSecret) GetSecretSpec() *SecretSpec {
+	return &mg.Spec.SecretSpec
+}
+
+// GetSecretStatus of this CodespacesSecret.
+func (mg *CodespacesSecret) GetSecretStatus() *SecretStatus {
+	return &mg.Status
+}
+
+//+kubebuilder:object:root=true
+
+// CodespacesSecretList contains a list of CodespacesSecret
+type CodespacesSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CodespacesSecret `json:"items"`
+}
+
+// GetItems of this CodespacesSecretList.
+func (l *CodespacesSecretList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
@@ -0,0 +1,53 @@
+// Package v1alpha1 contains API Schema definitions for the github v1alpha1 API group
+// +kubebuilder:object:generate=true
+// +groupName=github.krateo.io
+// +versionName=v1alpha1
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+// Package type metadata.
+const (
+	Group   = "github.krateo.io"
+	Version = "v1alpha1"
+)
+
+var (
+	// SchemeGroupVersion is group version used to register these objects
+	SchemeGroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
+)
+
+var (
+	ActionsSecretKind             = reflect.TypeOf(ActionsSecret{}).Name()
+	ActionsSecretGroupKind        = schema.GroupKind{Group: Group, Kind: ActionsSecretKind}.String()
+	ActionsSecretKindAPIVersion   = ActionsSecretKind + "." + SchemeGroupVersion.String()
+	ActionsSecretGroupVersionKind = SchemeGroupVersion.WithKind(ActionsSecretKind)
+)
+
+var (
+	DependabotSecretKind             = reflect.TypeOf(DependabotSecret{}).Name()
+	DependabotSecretGroupKind        = schema.GroupKind{Group: Group, Kind: DependabotSecretKind}.String()
+	DependabotSecretKindAPIVersion   = DependabotSecretKind + "." + SchemeGroupVersion.String()
+	DependabotSecretGroupVersionKind = SchemeGroupVersion.WithKind(DependabotSecretKind)
+)
+
+var (
+	CodespacesSecretKind             = reflect.TypeOf(CodespacesSecret{}).Name()
+	CodespacesSecretGroupKind        = schema.GroupKind{Group: Group, Kind: CodespacesSecretKind}.String()
+	CodespacesSecretKindAPIVersion   = CodespacesSecretKind + "." + SchemeGroupVersion.String()
+	CodespacesSecretGroupVersionKind = SchemeGroupVersion.WithKind(CodespacesSecretKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&ActionsSecret{}, &ActionsSecretList{})
+	SchemeBuilder.Register(&DependabotSecret{}, &DependabotSecretList{})
+	SchemeBuilder.Register(&CodespacesSecret{}, &CodespacesSecretList{})
+}
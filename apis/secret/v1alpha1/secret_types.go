@@ -0,0 +1,57 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SecretSpec defines the desired state of a secret, regardless of the
+// store it belongs to. Set org for an organization secret, or owner and
+// repo for a repository secret.
+type SecretSpec struct {
+	// Org: the organization the secret belongs to.
+	// +optional
+	// +immutable
+	Org string `json:"org,omitempty"`
+
+	// Owner: the account owner of the repository the secret belongs to.
+	// +optional
+	// +immutable
+	Owner string `json:"owner,omitempty"`
+
+	// Repo: the name of the repository the secret belongs to.
+	// +optional
+	// +immutable
+	Repo string `json:"repo,omitempty"`
+
+	// Name: the name of the secret.
+	// +immutable
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	Name string `json:"name"`
+
+	// ValueRef: the key of the Secret holding the plaintext value. The
+	// secret is written again when the value changes.
+	ValueRef prv1.SecretKeySelector `json:"valueRef"`
+
+	// Visibility: which repositories of the organization can access the
+	// secret (default: private). Only used by organization secrets.
+	// +optional
+	// +kubebuilder:validation:Enum=all;private;selected
+	Visibility *string `json:"visibility,omitempty"`
+
+	// SelectedRepositories: the names of the repositories that can access
+	// the secret when the visibility is selected.
+	// +optional
+	SelectedRepositories []string `json:"selectedRepositories,omitempty"`
+}
+
+// SecretStatus defines the observed state of a secret.
+type SecretStatus struct {
+	prv1.ConditionedStatus `json:",inline"`
+
+	// CreatedAt: when the secret was created.
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+
+	// UpdatedAt: when the secret was last updated.
+	UpdatedAt *metav1.Time `json:"updatedAt,omitempty"`
+}
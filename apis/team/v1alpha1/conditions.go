@@ -0,0 +1,22 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReasonPending reports that a membership waits for the user to accept
+// the invitation to join the organization.
+const ReasonPending prv1.ConditionReason = "Pending"
+
+// Pending returns a condition that indicates the membership exists but
+// the user has not accepted the invitation to join the organization yet.
+func Pending() prv1.Condition {
+	return prv1.Condition{
+		Type:               prv1.TypeReady,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPending,
+		Message:            "the user has not accepted the invitation to join the organization yet",
+	}
+}
@@ -0,0 +1,53 @@
+// Package v1alpha1 contains API Schema definitions for the github v1alpha1 API group
+// +kubebuilder:object:generate=true
+// +groupName=github.krateo.io
+// +versionName=v1alpha1
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+// Package type metadata.
+const (
+	Group   = "github.krateo.io"
+	Version = "v1alpha1"
+)
+
+var (
+	// SchemeGroupVersion is group version used to register these objects
+	SchemeGroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
+)
+
+var (
+	TeamKind             = reflect.TypeOf(Team{}).Name()
+	TeamGroupKind        = schema.GroupKind{Group: Group, Kind: TeamKind}.String()
+	TeamKindAPIVersion   = TeamKind + "." + SchemeGroupVersion.String()
+	TeamGroupVersionKind = SchemeGroupVersion.WithKind(TeamKind)
+)
+
+var (
+	TeamMembershipKind             = reflect.TypeOf(TeamMembership{}).Name()
+	TeamMembershipGroupKind        = schema.GroupKind{Group: Group, Kind: TeamMembershipKind}.String()
+	TeamMembershipKindAPIVersion   = TeamMembershipKind + "." + SchemeGroupVersion.String()
+	TeamMembershipGroupVersionKind = SchemeGroupVersion.WithKind(TeamMembershipKind)
+)
+
+var (
+	TeamMembersKind             = reflect.TypeOf(TeamMembers{}).Name()
+	TeamMembersGroupKind        = schema.GroupKind{Group: Group, Kind: TeamMembersKind}.String()
+	TeamMembersKindAPIVersion   = TeamMembersKind + "." + SchemeGroupVersion.String()
+	TeamMembersGroupVersionKind = SchemeGroupVersion.WithKind(TeamMembersKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Team{}, &TeamList{})
+	SchemeBuilder.Register(&TeamMembership{}, &TeamMembershipList{})
+	SchemeBuilder.Register(&TeamMembers{}, &TeamMembersList{})
+}
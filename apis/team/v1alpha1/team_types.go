@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TeamSpec defines the desired state of Team
+type TeamSpec struct {
+	// ApiUrl: the baseUrl for the REST API provider.
+	// +optional
+	// +immutable
+	ApiUrl string `json:"apiUrl,omitempty"`
+
+	// Credentials required to authenticate ReST API git server.
+	Credentials *prv1.CredentialSelectors `json:"credentials"`
+
+	// Verbose is true dumps your client requests and responses.
+	// +optional
+	Verbose *bool `json:"verbose,omitempty"`
+
+	// Org: the organization the team belongs to.
+	// +immutable
+	Org string `json:"org"`
+
+	// Name: the name of the team. Once the team is tracked by its id,
+	// changing it renames the team, and its slug changes accordingly.
+	Name string `json:"name"`
+
+	// Description: the description of the team.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Privacy: the level of privacy of the team. Secret teams are only
+	// visible to their members and to the owners of the organization;
+	// nested teams must be closed. Defaults to secret for top level teams
+	// and to closed for nested teams.
+	// +optional
+	// +kubebuilder:validation:Enum=secret;closed
+	Privacy *string `json:"privacy,omitempty"`
+
+	// NotificationSetting: whether the members of the team are notified
+	// when the team is mentioned (default: notifications_enabled).
+	// +optional
+	// +kubebuilder:validation:Enum=notifications_enabled;notifications_disabled
+	NotificationSetting *string `json:"notificationSetting,omitempty"`
+
+	// ParentTeam: the slug of the parent team. The team is a top level
+	// team when it is not set.
+	// +optional
+	ParentTeam *string `json:"parentTeam,omitempty"`
+}
+
+// TeamStatus defines the observed state of Team
+type TeamStatus struct {
+	prv1.ConditionedStatus `json:",inline"`
+
+	// Id: the numeric identifier of the team. The team is tracked by this
+	// id (also stored in the krateo.io/external-name annotation) so that
+	// it can be renamed.
+	Id *int64 `json:"id,omitempty"`
+
+	// Slug: the slug of the team, generated from its name.
+	Slug *string `json:"slug,omitempty"`
+
+	// Url: the URL of the team page.
+	Url *string `json:"url,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//+kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+//+kubebuilder:printcolumn:name="SLUG",type="string",JSONPath=".status.slug"
+//+kubebuilder:printcolumn:name="ID",type="integer",JSONPath=".status.id"
+
+// Team is the Schema for the teams API
+type Team struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TeamSpec   `json:"spec,omitempty"`
+	Status TeamStatus `json:"status,omitempty"`
+}
+
+// GetCondition of this Team.
+func (mg *Team) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// SetConditions of this Team.
+func (mg *Team) SetConditions(c ...prv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+//+kubebuilder:object:root=true
+
+// TeamList contains a list of Team
+type TeamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Team `json:"items"`
+}
+
+// GetItems of this TeamList.
+func (l *TeamList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
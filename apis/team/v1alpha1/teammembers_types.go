@@ -0,0 +1,117 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TeamMembersSpec defines the desired state of TeamMembers
+type TeamMembersSpec struct {
+	// ApiUrl: the baseUrl for the REST API provider.
+	// +optional
+	// +immutable
+	ApiUrl string `json:"apiUrl,omitempty"`
+
+	// Credentials required to authenticate ReST API git server.
+	Credentials *prv1.CredentialSelectors `json:"credentials"`
+
+	// Verbose is true dumps your client requests and responses.
+	// +optional
+	Verbose *bool `json:"verbose,omitempty"`
+
+	// Org: the organization the team belongs to.
+	// +immutable
+	Org string `json:"org"`
+
+	// TeamSlug: the slug of the team.
+	// +immutable
+	TeamSlug string `json:"teamSlug"`
+
+	// Members: the logins of the users with the member role. Together
+	// with the maintainers, they are the complete list of the members of
+	// the team: any other member is removed.
+	// +optional
+	Members []string `json:"members,omitempty"`
+
+	// Maintainers: the logins of the users with the maintainer role. A
+	// login cannot be listed both as member and as maintainer.
+	// +optional
+	Maintainers []string `json:"maintainers,omitempty"`
+
+	// MaxRemovals: the maximum number of members that can be removed in
+	// a single reconcile (default: 5). When more members would be removed,
+	// no change at all is made and the resource reports an error, to guard
+	// against mistakes. Set to -1 to remove any number of members.
+	// +optional
+	// +kubebuilder:validation:Minimum=-1
+	MaxRemovals *int `json:"maxRemovals,omitempty"`
+}
+
+// TeamMembersStatus defines the observed state of TeamMembers
+type TeamMembersStatus struct {
+	prv1.ConditionedStatus `json:",inline"`
+
+	// PendingAdditions: the users to add to the team.
+	// +optional
+	PendingAdditions []string `json:"pendingAdditions,omitempty"`
+
+	// PendingRemovals: the members to remove from the team.
+	// +optional
+	PendingRemovals []string `json:"pendingRemovals,omitempty"`
+
+	// PendingRoleChanges: the members whose role must change.
+	// +optional
+	PendingRoleChanges []string `json:"pendingRoleChanges,omitempty"`
+
+	// PendingInvitations: the users added to the team who have not
+	// accepted the invitation to join the organization yet.
+	// +optional
+	PendingInvitations []string `json:"pendingInvitations,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//+kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+//+kubebuilder:printcolumn:name="TEAM",type="string",JSONPath=".spec.teamSlug"
+
+// TeamMembers is the Schema for the teammembers API. It manages the
+// complete list of the members of a team; deleting it leaves the members
+// unchanged.
+type TeamMembers struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TeamMembersSpec   `json:"spec,omitempty"`
+	Status TeamMembersStatus `json:"status,omitempty"`
+}
+
+// GetCondition of this TeamMembers.
+func (mg *TeamMembers) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// SetConditions of this TeamMembers.
+func (mg *TeamMembers) SetConditions(c ...prv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+//+kubebuilder:object:root=true
+
+// TeamMembersList contains a list of TeamMembers
+type TeamMembersList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TeamMembers `json:"items"`
+}
+
+// GetItems of this TeamMembersList.
+func (l *TeamMembersList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
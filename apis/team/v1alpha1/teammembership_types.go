@@ -0,0 +1,101 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Roles of a team member.
+const (
+	TeamRoleMember     = "member"
+	TeamRoleMaintainer = "maintainer"
+)
+
+// TeamMembershipSpec defines the desired state of TeamMembership
+type TeamMembershipSpec struct {
+	// ApiUrl: the baseUrl for the REST API provider.
+	// +optional
+	// +immutable
+	ApiUrl string `json:"apiUrl,omitempty"`
+
+	// Credentials required to authenticate ReST API git server.
+	Credentials *prv1.CredentialSelectors `json:"credentials"`
+
+	// Verbose is true dumps your client requests and responses.
+	// +optional
+	Verbose *bool `json:"verbose,omitempty"`
+
+	// Org: the organization the team belongs to.
+	// +immutable
+	Org string `json:"org"`
+
+	// TeamSlug: the slug of the team.
+	// +immutable
+	TeamSlug string `json:"teamSlug"`
+
+	// Username: the login of the user. Users who are not members of the
+	// organization are invited to join it.
+	// +immutable
+	Username string `json:"username"`
+
+	// Role: the role of the user in the team (default: member).
+	// +optional
+	// +kubebuilder:validation:Enum=member;maintainer
+	Role *string `json:"role,omitempty"`
+}
+
+// TeamMembershipStatus defines the observed state of TeamMembership
+type TeamMembershipStatus struct {
+	prv1.ConditionedStatus `json:",inline"`
+
+	// State: the state of the membership, active or pending until the
+	// user accepts the invitation to join the organization.
+	State *string `json:"state,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//+kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+//+kubebuilder:printcolumn:name="TEAM",type="string",JSONPath=".spec.teamSlug"
+//+kubebuilder:printcolumn:name="USER",type="string",JSONPath=".spec.username"
+//+kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state"
+
+// TeamMembership is the Schema for the teammemberships API
+type TeamMembership struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TeamMembershipSpec   `json:"spec,omitempty"`
+	Status TeamMembershipStatus `json:"status,omitempty"`
+}
+
+// GetCondition of this TeamMembership.
+func (mg *TeamMembership) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// SetConditions of this TeamMembership.
+func (mg *TeamMembership) SetConditions(c ...prv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+//+kubebuilder:object:root=true
+
+// TeamMembershipList contains a list of TeamMembership
+type TeamMembershipList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TeamMembership `json:"items"`
+}
+
+// GetItems of this TeamMembershipList.
+func (l *TeamMembershipList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
@@ -0,0 +1,125 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ActionsVariableSpec defines the desired state of ActionsVariable. Set org
+// for an organization variable, or owner and repo for a repository (or
+// environment) variable.
+type ActionsVariableSpec struct {
+	// ApiUrl: the baseUrl for the REST API provider.
+	// +optional
+	// +immutable
+	ApiUrl string `json:"apiUrl,omitempty"`
+
+	// Credentials required to authenticate ReST API git server.
+	Credentials *prv1.CredentialSelectors `json:"credentials"`
+
+	// Verbose is true dumps your client requests and responses.
+	// +optional
+	Verbose *bool `json:"verbose,omitempty"`
+
+	// Org: the organization the variable belongs to.
+	// +optional
+	// +immutable
+	Org string `json:"org,omitempty"`
+
+	// Owner: the account owner of the repository the variable belongs to.
+	// +optional
+	// +immutable
+	Owner string `json:"owner,omitempty"`
+
+	// Repo: the name of the repository the variable belongs to.
+	// +optional
+	// +immutable
+	Repo string `json:"repo,omitempty"`
+
+	// Environment: the name of the repository environment the variable
+	// belongs to. Requires owner and repo.
+	// +optional
+	// +immutable
+	Environment string `json:"environment,omitempty"`
+
+	// Name: the name of the variable.
+	// +immutable
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	Name string `json:"name"`
+
+	// Value: the value of the variable. Takes precedence over valueFrom.
+	// +optional
+	Value *string `json:"value,omitempty"`
+
+	// ValueFrom: the key of the ConfigMap holding the value of the variable.
+	// +optional
+	ValueFrom *prv1.ConfigMapKeySelector `json:"valueFrom,omitempty"`
+
+	// Visibility: which repositories of the organization can access the
+	// variable (default: private). Only used by organization variables.
+	// +optional
+	// +kubebuilder:validation:Enum=all;private;selected
+	Visibility *string `json:"visibility,omitempty"`
+
+	// SelectedRepositories: the names of the repositories that can access
+	// the variable when the visibility is selected.
+	// +optional
+	SelectedRepositories []string `json:"selectedRepositories,omitempty"`
+}
+
+// ActionsVariableStatus defines the observed state of ActionsVariable
+type ActionsVariableStatus struct {
+	prv1.ConditionedStatus `json:",inline"`
+
+	// CreatedAt: when the variable was created.
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+
+	// UpdatedAt: when the variable was last updated.
+	UpdatedAt *metav1.Time `json:"updatedAt,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//+kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+//+kubebuilder:printcolumn:name="VARIABLE",type="string",JSONPath=".spec.name"
+//+kubebuilder:printcolumn:name="UPDATED",type="date",JSONPath=".status.updatedAt"
+
+// ActionsVariable is the Schema for the actionsvariables API
+type ActionsVariable struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ActionsVariableSpec   `json:"spec,omitempty"`
+	Status ActionsVariableStatus `json:"status,omitempty"`
+}
+
+// GetCondition of this ActionsVariable.
+func (mg *ActionsVariable) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// SetConditions of this ActionsVariable.
+func (mg *ActionsVariable) SetConditions(c ...prv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+//+kubebuilder:object:root=true
+
+// ActionsVariableList contains a list of ActionsVariable
+type ActionsVariableList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ActionsVariable `json:"items"`
+}
+
+// GetItems of this ActionsVariableList.
+func (l *ActionsVariableList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
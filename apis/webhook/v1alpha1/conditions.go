@@ -0,0 +1,52 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TypeDelivered reports whether the last delivery of a webhook succeeded.
+const TypeDelivered prv1.ConditionType = "Delivered"
+
+// Reasons the last delivery of a webhook did or did not succeed.
+const (
+	ReasonDeliverySucceeded prv1.ConditionReason = "DeliverySucceeded"
+	ReasonDeliveryFailed    prv1.ConditionReason = "DeliveryFailed"
+	ReasonNoDelivery        prv1.ConditionReason = "NoDelivery"
+)
+
+// DeliverySucceeded returns a condition that indicates the last delivery
+// of the webhook succeeded.
+func DeliverySucceeded() prv1.Condition {
+	return prv1.Condition{
+		Type:               TypeDelivered,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeliverySucceeded,
+	}
+}
+
+// DeliveryFailed returns a condition that indicates the last delivery of
+// the webhook failed with the given response.
+func DeliveryFailed(code int, message string) prv1.Condition {
+	return prv1.Condition{
+		Type:               TypeDelivered,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeliveryFailed,
+		Message:            fmt.Sprintf("last delivery failed with status %d: %s", code, message),
+	}
+}
+
+// NoDelivery returns a condition that indicates the webhook has not been
+// delivered yet.
+func NoDelivery() prv1.Condition {
+	return prv1.Condition{
+		Type:               TypeDelivered,
+		Status:             metav1.ConditionUnknown,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoDelivery,
+	}
+}
@@ -0,0 +1,45 @@
+// Package v1alpha1 contains API Schema definitions for the github v1alpha1 API group
+// +kubebuilder:object:generate=true
+// +groupName=github.krateo.io
+// +versionName=v1alpha1
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+// Package type metadata.
+const (
+	Group   = "github.krateo.io"
+	Version = "v1alpha1"
+)
+
+var (
+	// SchemeGroupVersion is group version used to register these objects
+	SchemeGroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
+)
+
+var (
+	RepoWebhookKind             = reflect.TypeOf(RepoWebhook{}).Name()
+	RepoWebhookGroupKind        = schema.GroupKind{Group: Group, Kind: RepoWebhookKind}.String()
+	RepoWebhookKindAPIVersion   = RepoWebhookKind + "." + SchemeGroupVersion.String()
+	RepoWebhookGroupVersionKind = SchemeGroupVersion.WithKind(RepoWebhookKind)
+)
+
+var (
+	OrgWebhookKind             = reflect.TypeOf(OrgWebhook{}).Name()
+	OrgWebhookGroupKind        = schema.GroupKind{Group: Group, Kind: OrgWebhookKind}.String()
+	OrgWebhookKindAPIVersion   = OrgWebhookKind + "." + SchemeGroupVersion.String()
+	OrgWebhookGroupVersionKind = SchemeGroupVersion.WithKind(OrgWebhookKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RepoWebhook{}, &RepoWebhookList{})
+	SchemeBuilder.Register(&OrgWebhook{}, &OrgWebhookList{})
+}
@@ -0,0 +1,82 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OrgWebhookSpec defines the desired state of OrgWebhook
+type OrgWebhookSpec struct {
+	// ApiUrl: the baseUrl for the REST API provider.
+	// +optional
+	// +immutable
+	ApiUrl string `json:"apiUrl,omitempty"`
+
+	// Credentials required to authenticate ReST API git server.
+	Credentials *prv1.CredentialSelectors `json:"credentials"`
+
+	// Verbose is true dumps your client requests and responses.
+	// +optional
+	Verbose *bool `json:"verbose,omitempty"`
+
+	// Org: the organization name. The name is not case sensitive.
+	// +immutable
+	Org string `json:"org"`
+
+	WebhookSpec `json:",inline"`
+}
+
+// OrgWebhookStatus defines the observed state of OrgWebhook
+type OrgWebhookStatus struct {
+	prv1.ConditionedStatus `json:",inline"`
+
+	// Id: the numeric identifier of the webhook.
+	Id *int64 `json:"id,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//+kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+//+kubebuilder:printcolumn:name="URL",type="string",JSONPath=".spec.url"
+//+kubebuilder:printcolumn:name="DELIVERED",type="string",JSONPath=".status.conditions[?(@.type=='Delivered')].status"
+//+kubebuilder:printcolumn:name="ID",type="integer",JSONPath=".status.id",priority=1
+
+// OrgWebhook is the Schema for the orgwebhooks API
+type OrgWebhook struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrgWebhookSpec   `json:"spec,omitempty"`
+	Status OrgWebhookStatus `json:"status,omitempty"`
+}
+
+// GetCondition of this OrgWebhook.
+func (mg *OrgWebhook) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// SetConditions of this OrgWebhook.
+func (mg *OrgWebhook) SetConditions(c ...prv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+//+kubebuilder:object:root=true
+
+// OrgWebhookList contains a list of OrgWebhook
+type OrgWebhookList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrgWebhook `json:"items"`
+}
+
+// GetItems of this OrgWebhookList.
+func (l *OrgWebhookList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
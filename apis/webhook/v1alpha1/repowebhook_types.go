@@ -0,0 +1,85 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RepoWebhookSpec defines the desired state of RepoWebhook
+type RepoWebhookSpec struct {
+	// ApiUrl: the baseUrl for the REST API provider.
+	// +optional
+	// +immutable
+	ApiUrl string `json:"apiUrl,omitempty"`
+
+	// Credentials required to authenticate ReST API git server.
+	Credentials *prv1.CredentialSelectors `json:"credentials"`
+
+	// Verbose is true dumps your client requests and responses.
+	// +optional
+	Verbose *bool `json:"verbose,omitempty"`
+
+	// Owner: the account owner of the repository. The name is not case sensitive.
+	// +immutable
+	Owner string `json:"owner"`
+
+	// Repo: the name of the repository without the .git extension. The name is not case sensitive.
+	// +immutable
+	Repo string `json:"repo"`
+
+	WebhookSpec `json:",inline"`
+}
+
+// RepoWebhookStatus defines the observed state of RepoWebhook
+type RepoWebhookStatus struct {
+	prv1.ConditionedStatus `json:",inline"`
+
+	// Id: the numeric identifier of the webhook.
+	Id *int64 `json:"id,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories={krateo,github}
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//+kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+//+kubebuilder:printcolumn:name="URL",type="string",JSONPath=".spec.url"
+//+kubebuilder:printcolumn:name="ID",type="integer",JSONPath=".status.id",priority=1
+
+// RepoWebhook is the Schema for the repowebhooks API
+type RepoWebhook struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepoWebhookSpec   `json:"spec,omitempty"`
+	Status RepoWebhookStatus `json:"status,omitempty"`
+}
+
+// GetCondition of this RepoWebhook.
+func (mg *RepoWebhook) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// SetConditions of this RepoWebhook.
+func (mg *RepoWebhook) SetConditions(c ...prv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+//+kubebuilder:object:root=true
+
+// RepoWebhookList contains a list of RepoWebhook
+type RepoWebhookList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RepoWebhook `json:"items"`
+}
+
+// GetItems of this RepoWebhookList.
+func (l *RepoWebhookList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
@@ -0,0 +1,36 @@
+package v1alpha1
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+)
+
+// WebhookSpec defines the desired state of a webhook, regardless of the
+// level it is defined at.
+type WebhookSpec struct {
+	// Url: the URL to which the payloads are delivered.
+	Url string `json:"url"`
+
+	// ContentType: the media type used to serialize the payloads (default: form).
+	// +optional
+	// +kubebuilder:validation:Enum=json;form
+	ContentType *string `json:"contentType,omitempty"`
+
+	// Events: the events that trigger the webhook (default: push).
+	// +optional
+	Events []string `json:"events,omitempty"`
+
+	// Active: whether notifications are sent when the webhook is triggered (default: true).
+	// +optional
+	Active *bool `json:"active,omitempty"`
+
+	// InsecureSsl: whether the SSL certificate of the host is not verified
+	// when delivering payloads (default: false).
+	// +optional
+	InsecureSsl *bool `json:"insecureSsl,omitempty"`
+
+	// SecretRef: the key of the Secret holding the secret used to sign the
+	// payloads with an HMAC hex digest. The webhook secret is rotated when
+	// the value changes.
+	// +optional
+	SecretRef *prv1.SecretKeySelector `json:"secretRef,omitempty"`
+}
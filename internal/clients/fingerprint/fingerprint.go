@@ -0,0 +1,153 @@
+// Package fingerprint keeps track of the values written to GitHub fields
+// that can be set but are never returned, like secret values and webhook
+// secrets.
+//
+// The fingerprint of a value is an HMAC-SHA256 bound to the UID of the
+// resource, so that it can be stored in the annotations of the resource
+// without disclosing the value. It is keyed with a random key the provider
+// keeps in a Secret of the namespace of the resource, which does not change
+// when the credentials are rotated. A new value is
+// recorded in two steps, both persisted by the managed reconciler: Observe
+// stages its fingerprint before the resource is updated and confirms it
+// once GitHub reports the field updated.
+package fingerprint
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationValue is the fingerprint of the value last written.
+	AnnotationValue = "github.krateo.io/value-fingerprint"
+	// AnnotationPending is the fingerprint of the value being written.
+	AnnotationPending = "github.krateo.io/pending-value-fingerprint"
+	// AnnotationUpdatedAt is when GitHub reported the field last updated
+	// after the value was written or staged.
+	AnnotationUpdatedAt = "github.krateo.io/value-updated-at"
+
+	// KeySecretName is the name of the Secret holding the key of the
+	// fingerprints of the resources of a namespace.
+	KeySecretName = "github-provider-fingerprint-key"
+	// KeySecretKey is the key of the Secret data holding the key.
+	KeySecretKey = "key"
+)
+
+// Key returns the key of the fingerprints of the resources of the
+// namespace. The key is generated, and its Secret created, the first time
+// it is needed.
+func Key(ctx context.Context, kube client.Client, namespace string) (string, error) {
+	secret := &corev1.Secret{}
+	err := kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: KeySecretName}, secret)
+	if err == nil {
+		key := secret.Data[KeySecretKey]
+		if len(key) == 0 {
+			return "", fmt.Errorf("secret '%s/%s' has no '%s' key", namespace, KeySecretName, KeySecretKey)
+		}
+		return string(key), nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return "", err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	key := hex.EncodeToString(b)
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: KeySecretName},
+		Data:       map[string][]byte{KeySecretKey: []byte(key)},
+	}
+	err = kube.Create(ctx, secret)
+	if apierrors.IsAlreadyExists(err) {
+		// Created meanwhile by another reconcile.
+		return Key(ctx, kube, namespace)
+	}
+	if err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// Sum returns the fingerprint of value for the object with the given UID.
+func Sum(key string, uid types.UID, value string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(uid))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Record records that the value with the given fingerprint has been
+// written and that GitHub reported the field updated at updatedAt. The
+// annotations set by Create are persisted by the managed reconciler.
+func Record(o metav1.Object, sum string, updatedAt time.Time) {
+	meta.AddAnnotations(o, map[string]string{
+		AnnotationValue:     sum,
+		AnnotationUpdatedAt: formatTime(updatedAt),
+	})
+	meta.RemoveAnnotations(o, AnnotationPending)
+}
+
+// Stage records that the value with the given fingerprint is about to be
+// written while GitHub reports the field updated at updatedAt. It returns
+// whether the annotations changed.
+func Stage(o metav1.Object, sum string, updatedAt time.Time) bool {
+	if o.GetAnnotations()[AnnotationPending] == sum {
+		return false
+	}
+	meta.AddAnnotations(o, map[string]string{
+		AnnotationPending:   sum,
+		AnnotationUpdatedAt: formatTime(updatedAt),
+	})
+	return true
+}
+
+// Observe compares the fingerprint of the desired value with the recorded
+// ones, GitHub reporting the field updated at updatedAt. It returns whether
+// the value must be written, and whether the annotations changed and must
+// be persisted by reporting the resource late initialized.
+//
+// A staged value is confirmed as soon as the field is reported updated
+// after it was staged. With strict set, a field updated at any other time
+// than recorded is written again, since someone else overwrote it.
+func Observe(o metav1.Object, sum string, updatedAt time.Time, strict bool) (write, changed bool) {
+	a := o.GetAnnotations()
+	at := formatTime(updatedAt)
+
+	if pending, ok := a[AnnotationPending]; ok && pending == sum {
+		if a[AnnotationUpdatedAt] == at {
+			return true, false
+		}
+		Record(o, sum, updatedAt)
+		return false, true
+	}
+
+	if a[AnnotationValue] == sum && (!strict || a[AnnotationUpdatedAt] == at) {
+		if _, ok := a[AnnotationPending]; ok {
+			meta.RemoveAnnotations(o, AnnotationPending)
+			return false, true
+		}
+		return false, false
+	}
+
+	return true, Stage(o, sum, updatedAt)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,211 @@
+package fingerprint
+
+import (
+	"context"
+	"maps"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		objects  []corev1.Secret
+		expected string
+		err      bool
+	}{
+		{
+			name: "existing key",
+			objects: []corev1.Secret{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: KeySecretName},
+				Data:       map[string][]byte{KeySecretKey: []byte("secret")},
+			}},
+			expected: "secret",
+		},
+		{
+			name: "key of another namespace",
+			objects: []corev1.Secret{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: KeySecretName},
+				Data:       map[string][]byte{KeySecretKey: []byte("secret")},
+			}},
+		},
+		{
+			name:    "no key",
+			objects: nil,
+		},
+		{
+			name: "empty key",
+			objects: []corev1.Secret{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: KeySecretName},
+			}},
+			err: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := fake.NewClientBuilder()
+			for i := range tc.objects {
+				b = b.WithObjects(&tc.objects[i])
+			}
+			kube := b.Build()
+
+			key, err := Key(context.Background(), kube, "demo")
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got key %q", key)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tc.expected) > 0 && key != tc.expected {
+				t.Fatalf("expected key %q, got %q", tc.expected, key)
+			}
+			if len(key) == 0 {
+				t.Fatalf("expected a key")
+			}
+
+			// The key does not change once generated.
+			again, err := Key(context.Background(), kube, "demo")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again != key {
+				t.Fatalf("expected the same key %q, got %q", key, again)
+			}
+		})
+	}
+}
+
+func TestSum(t *testing.T) {
+	sum := Sum("key", "uid", "value")
+
+	tests := []struct {
+		name  string
+		key   string
+		uid   types.UID
+		value string
+		same  bool
+	}{
+		{name: "same inputs", key: "key", uid: "uid", value: "value", same: true},
+		{name: "other key", key: "other", uid: "uid", value: "value"},
+		{name: "other object", key: "key", uid: "other", value: "value"},
+		{name: "other value", key: "key", uid: "uid", value: "other"},
+		{name: "shifted boundary", key: "key", uid: "uidv", value: "alue"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Sum(tc.key, tc.uid, tc.value); (got == sum) != tc.same {
+				t.Fatalf("expected the same fingerprint: %v, got %s (base: %s)", tc.same, got, sum)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	t0 := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+	at0, at1 := formatTime(t0), formatTime(t1)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		sum         string
+		updatedAt   time.Time
+		strict      bool
+		write       bool
+		changed     bool
+		expected    map[string]string
+	}{
+		{
+			name:        "nothing recorded",
+			annotations: nil,
+			sum:         "a",
+			updatedAt:   t0,
+			write:       true,
+			changed:     true,
+			expected:    map[string]string{AnnotationPending: "a", AnnotationUpdatedAt: at0},
+		},
+		{
+			name:        "recorded",
+			annotations: map[string]string{AnnotationValue: "a", AnnotationUpdatedAt: at0},
+			sum:         "a",
+			updatedAt:   t0,
+			expected:    map[string]string{AnnotationValue: "a", AnnotationUpdatedAt: at0},
+		},
+		{
+			name:        "value changed",
+			annotations: map[string]string{AnnotationValue: "a", AnnotationUpdatedAt: at0},
+			sum:         "b",
+			updatedAt:   t0,
+			write:       true,
+			changed:     true,
+			expected:    map[string]string{AnnotationValue: "a", AnnotationPending: "b", AnnotationUpdatedAt: at0},
+		},
+		{
+			name:        "updated by someone else",
+			annotations: map[string]string{AnnotationValue: "a", AnnotationUpdatedAt: at0},
+			sum:         "a",
+			updatedAt:   t1,
+			strict:      true,
+			write:       true,
+			changed:     true,
+			expected:    map[string]string{AnnotationValue: "a", AnnotationPending: "a", AnnotationUpdatedAt: at1},
+		},
+		{
+			name:        "updated along with other settings",
+			annotations: map[string]string{AnnotationValue: "a", AnnotationUpdatedAt: at0},
+			sum:         "a",
+			updatedAt:   t1,
+			expected:    map[string]string{AnnotationValue: "a", AnnotationUpdatedAt: at0},
+		},
+		{
+			name:        "staged, not written yet",
+			annotations: map[string]string{AnnotationValue: "a", AnnotationPending: "b", AnnotationUpdatedAt: at0},
+			sum:         "b",
+			updatedAt:   t0,
+			write:       true,
+			expected:    map[string]string{AnnotationValue: "a", AnnotationPending: "b", AnnotationUpdatedAt: at0},
+		},
+		{
+			name:        "staged and written",
+			annotations: map[string]string{AnnotationValue: "a", AnnotationPending: "b", AnnotationUpdatedAt: at0},
+			sum:         "b",
+			updatedAt:   t1,
+			strict:      true,
+			changed:     true,
+			expected:    map[string]string{AnnotationValue: "b", AnnotationUpdatedAt: at1},
+		},
+		{
+			name:        "staged value reverted",
+			annotations: map[string]string{AnnotationValue: "a", AnnotationPending: "b", AnnotationUpdatedAt: at0},
+			sum:         "a",
+			updatedAt:   t0,
+			strict:      true,
+			changed:     true,
+			expected:    map[string]string{AnnotationValue: "a", AnnotationUpdatedAt: at0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			o := &metav1.ObjectMeta{Annotations: maps.Clone(tc.annotations)}
+
+			write, changed := Observe(o, tc.sum, tc.updatedAt, tc.strict)
+			if write != tc.write || changed != tc.changed {
+				t.Fatalf("expected write %v and changed %v, got %v and %v", tc.write, tc.changed, write, changed)
+			}
+			if !maps.Equal(o.GetAnnotations(), tc.expected) {
+				t.Fatalf("expected annotations %v, got %v", tc.expected, o.GetAnnotations())
+			}
+		})
+	}
+}
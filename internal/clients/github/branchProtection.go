@@ -0,0 +1,262 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/carlmjohnson/requests"
+	"github.com/krateoplatformops/github-provider/apis/branchProtection/v1alpha1"
+)
+
+// BranchProtectionService provides methods for managing the protection of branches.
+type BranchProtectionService struct {
+	client       *http.Client
+	apiUrl       string
+	apiExtraPath string
+	token        string
+}
+
+// BranchProtection represents the protection of a branch.
+type BranchProtection struct {
+	RequiredStatusChecks           *ProtectionStatusChecks `json:"required_status_checks"`
+	RequiredPullRequestReviews     *ProtectionReviews      `json:"required_pull_request_reviews"`
+	EnforceAdmins                  *ProtectionToggle       `json:"enforce_admins"`
+	RequiredLinearHistory          *ProtectionToggle       `json:"required_linear_history"`
+	AllowForcePushes               *ProtectionToggle       `json:"allow_force_pushes"`
+	AllowDeletions                 *ProtectionToggle       `json:"allow_deletions"`
+	RequiredConversationResolution *ProtectionToggle       `json:"required_conversation_resolution"`
+	RequiredSignatures             *ProtectionToggle       `json:"required_signatures"`
+	Restrictions                   *ProtectionActors       `json:"restrictions"`
+}
+
+// ProtectionStatusChecks are the status checks required by a branch protection.
+type ProtectionStatusChecks struct {
+	Strict bool `json:"strict"`
+	Checks []struct {
+		Context string `json:"context"`
+		AppID   *int64 `json:"app_id"`
+	} `json:"checks"`
+}
+
+// ProtectionReviews are the pull request reviews required by a branch protection.
+type ProtectionReviews struct {
+	RequiredApprovingReviewCount int               `json:"required_approving_review_count"`
+	RequireCodeOwnerReviews      bool              `json:"require_code_owner_reviews"`
+	DismissStaleReviews          bool              `json:"dismiss_stale_reviews"`
+	RequireLastPushApproval      bool              `json:"require_last_push_approval"`
+	DismissalRestrictions        *ProtectionActors `json:"dismissal_restrictions"`
+	BypassPullRequestAllowances  *ProtectionActors `json:"bypass_pull_request_allowances"`
+}
+
+// ProtectionToggle is a branch protection setting that is either enabled or not.
+type ProtectionToggle struct {
+	Enabled bool `json:"enabled"`
+}
+
+// IsEnabled reports whether the setting is enabled.
+func (t *ProtectionToggle) IsEnabled() bool {
+	return t != nil && t.Enabled
+}
+
+// ProtectionActors are the users, teams and apps a branch protection setting applies to.
+type ProtectionActors struct {
+	Users []struct {
+		Login string `json:"login"`
+	} `json:"users"`
+	Teams []struct {
+		Slug string `json:"slug"`
+	} `json:"teams"`
+	Apps []struct {
+		Slug string `json:"slug"`
+	} `json:"apps"`
+}
+
+// ToSpec converts the actors into their spec form.
+func (a *ProtectionActors) ToSpec() *v1alpha1.Actors {
+	if a == nil {
+		return nil
+	}
+
+	res := &v1alpha1.Actors{}
+	for _, u := range a.Users {
+		res.Users = append(res.Users, u.Login)
+	}
+	for _, t := range a.Teams {
+		res.Teams = append(res.Teams, t.Slug)
+	}
+	for _, x := range a.Apps {
+		res.Apps = append(res.Apps, x.Slug)
+	}
+	return res
+}
+
+// newBranchProtectionService returns a new BranchProtectionService.
+func newBranchProtectionService(httpClient *http.Client, apiUrl, extraPath, token string) *BranchProtectionService {
+	return &BranchProtectionService{
+		client:       httpClient,
+		apiUrl:       apiUrl,
+		apiExtraPath: extraPath,
+		token:        token,
+	}
+}
+
+// Get fetches the protection of a branch. It returns nil if the branch is not protected.
+//
+// GitHub API docs: https://docs.github.com/en/rest/branches/branch-protection#get-branch-protection
+func (s *BranchProtectionService) Get(opts *v1alpha1.BranchProtectionSpec) (*BranchProtection, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/branches/%s/protection", opts.Owner, opts.Repo, opts.Branch))
+
+	res := &BranchProtection{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Update protects a branch, replacing its whole protection with the desired one.
+//
+// GitHub API docs: https://docs.github.com/en/rest/branches/branch-protection#update-branch-protection
+func (s *BranchProtectionService) Update(opts *v1alpha1.BranchProtectionSpec) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/branches/%s/protection", opts.Owner, opts.Repo, opts.Branch))
+
+	body := map[string]interface{}{
+		"required_status_checks":           nil,
+		"required_pull_request_reviews":    nil,
+		"restrictions":                     nil,
+		"enforce_admins":                   opts.EnforceAdmins,
+		"required_linear_history":          opts.RequiredLinearHistory,
+		"allow_force_pushes":               opts.AllowForcePushes,
+		"allow_deletions":                  opts.AllowDeletions,
+		"required_conversation_resolution": opts.RequiredConversationResolution,
+	}
+
+	if rsc := opts.RequiredStatusChecks; rsc != nil {
+		checks := []map[string]interface{}{}
+		for _, c := range rsc.Checks {
+			check := map[string]interface{}{"context": c.Context}
+			setIfNotNil(check, "app_id", c.AppId)
+			checks = append(checks, check)
+		}
+		body["required_status_checks"] = map[string]interface{}{
+			"strict": rsc.Strict,
+			"checks": checks,
+		}
+	}
+
+	if rpr := opts.RequiredPullRequestReviews; rpr != nil {
+		reviews := map[string]interface{}{
+			"required_approving_review_count": rpr.RequiredApprovingReviewCount,
+			"require_code_owner_reviews":      rpr.RequireCodeOwnerReviews,
+			"dismiss_stale_reviews":           rpr.DismissStaleReviews,
+			"require_last_push_approval":      rpr.RequireLastPushApproval,
+		}
+		if rpr.DismissalRestrictions != nil {
+			reviews["dismissal_restrictions"] = actorsBody(rpr.DismissalRestrictions)
+		}
+		if rpr.BypassPullRequestAllowances != nil {
+			reviews["bypass_pull_request_allowances"] = actorsBody(rpr.BypassPullRequestAllowances)
+		}
+		body["required_pull_request_reviews"] = reviews
+	}
+
+	if opts.Restrictions != nil {
+		body["restrictions"] = actorsBody(opts.Restrictions)
+	}
+
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPut).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, 200)).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return fmt.Errorf(gerr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// SetRequiredSignatures enables or disables the requirement of signed
+// commits on a protected branch.
+//
+// GitHub API docs: https://docs.github.com/en/rest/branches/branch-protection#create-commit-signature-protection
+func (s *BranchProtectionService) SetRequiredSignatures(opts *v1alpha1.BranchProtectionSpec, enabled bool) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/branches/%s/protection/required_signatures", opts.Owner, opts.Repo, opts.Branch))
+
+	method, status := http.MethodDelete, 204
+	if enabled {
+		method, status = http.MethodPost, 200
+	}
+
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(method).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		AddValidator(ErrorJSON(githubError, status)).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return fmt.Errorf(gerr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the protection of a branch.
+//
+// GitHub API docs: https://docs.github.com/en/rest/branches/branch-protection#delete-branch-protection
+func (s *BranchProtectionService) Delete(opts *v1alpha1.BranchProtectionSpec) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/branches/%s/protection", opts.Owner, opts.Repo, opts.Branch))
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodDelete).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(204).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func actorsBody(a *v1alpha1.Actors) map[string]interface{} {
+	return map[string]interface{}{
+		"users": nonNil(a.Users),
+		"teams": nonNil(a.Teams),
+		"apps":  nonNil(a.Apps),
+	}
+}
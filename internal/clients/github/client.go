@@ -40,6 +40,20 @@ type Client struct {
 	repos         *RepoService
 	collaborators *CollaboratorService
 	teamRepo     *TeamRepoService
+	branchProtection *BranchProtectionService
+	repositoryRuleset *RepositoryRulesetService
+	organizationRuleset *OrganizationRulesetService
+	repoWebhook *RepoWebhookService
+	orgWebhook *OrgWebhookService
+	deployKeys *DeployKeyService
+	actionsSecrets *SecretService
+	dependabotSecrets *SecretService
+	codespacesSecrets *SecretService
+	actionsVariables *VariableService
+	users *UserService
+	teams *TeamService
+	environments *EnvironmentService
+	orgMemberships *OrgMembershipService
 }
 
 // NewClient returns a new Github Client
@@ -64,6 +78,20 @@ func NewClient(opts ClientOpts) *Client {
 	res.repos = newRepoService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token)
 	res.collaborators = newCollaboratorService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token)
 	res.teamRepo = newTeamRepoService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token)
+	res.branchProtection = newBranchProtectionService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token)
+	res.repositoryRuleset = newRepositoryRulesetService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token)
+	res.organizationRuleset = newOrganizationRulesetService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token)
+	res.repoWebhook = newRepoWebhookService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token)
+	res.orgWebhook = newOrgWebhookService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token)
+	res.deployKeys = newDeployKeyService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token)
+	res.actionsSecrets = newSecretService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token, "actions")
+	res.dependabotSecrets = newSecretService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token, "dependabot")
+	res.codespacesSecrets = newSecretService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token, "codespaces")
+	res.actionsVariables = newVariableService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token)
+	res.users = newUserService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token)
+	res.teams = newTeamService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token)
+	res.environments = newEnvironmentService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token)
+	res.orgMemberships = newOrgMembershipService(res.httpClient, res.apiUrl, res.apiExtraPath, opts.Token)
 
 	return res
 }
@@ -78,3 +106,59 @@ func (c *Client) Collaborators() *CollaboratorService {
 func (c *Client) TeamRepo() *TeamRepoService {
 	return c.teamRepo
 }
+
+func (c *Client) BranchProtection() *BranchProtectionService {
+	return c.branchProtection
+}
+
+func (c *Client) RepositoryRuleset() *RepositoryRulesetService {
+	return c.repositoryRuleset
+}
+
+func (c *Client) OrganizationRuleset() *OrganizationRulesetService {
+	return c.organizationRuleset
+}
+
+func (c *Client) RepoWebhook() *RepoWebhookService {
+	return c.repoWebhook
+}
+
+func (c *Client) OrgWebhook() *OrgWebhookService {
+	return c.orgWebhook
+}
+
+func (c *Client) DeployKeys() *DeployKeyService {
+	return c.deployKeys
+}
+
+func (c *Client) ActionsSecrets() *SecretService {
+	return c.actionsSecrets
+}
+
+func (c *Client) DependabotSecrets() *SecretService {
+	return c.dependabotSecrets
+}
+
+func (c *Client) CodespacesSecrets() *SecretService {
+	return c.codespacesSecrets
+}
+
+func (c *Client) ActionsVariables() *VariableService {
+	return c.actionsVariables
+}
+
+func (c *Client) Users() *UserService {
+	return c.users
+}
+
+func (c *Client) Teams() *TeamService {
+	return c.teams
+}
+
+func (c *Client) Environments() *EnvironmentService {
+	return c.environments
+}
+
+func (c *Client) OrgMemberships() *OrgMembershipService {
+	return c.orgMemberships
+}
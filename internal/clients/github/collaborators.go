@@ -1,11 +1,15 @@
 package github
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"path"
+	"strings"
+	"time"
 
 	"github.com/carlmjohnson/requests"
 	"github.com/krateoplatformops/github-provider/apis/collaborator/v1alpha1"
@@ -24,6 +28,25 @@ type CollaboratorPermission struct {
 	RoleName   string `json:"role_name"`
 }
 
+// invitationTTL is how long repository invitations are valid.
+const invitationTTL = 7 * 24 * time.Hour
+
+const repositoryInvitationsPerPage = 100
+
+// RepositoryInvitation represents an invitation to collaborate on a repository.
+type RepositoryInvitation struct {
+	ID          int64     `json:"id"`
+	Permissions string    `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+	Expired     bool      `json:"expired"`
+	Invitee     *User     `json:"invitee"`
+}
+
+// ExpiresAt returns when the invitation expires.
+func (inv *RepositoryInvitation) ExpiresAt() time.Time {
+	return inv.CreatedAt.Add(invitationTTL)
+}
+
 // newCollaboratorService returns a new CollaboratorService.
 func newCollaboratorService(httpClient *http.Client, apiUrl, extraPath, token string) *CollaboratorService {
 	return &CollaboratorService{
@@ -34,37 +57,55 @@ func newCollaboratorService(httpClient *http.Client, apiUrl, extraPath, token st
 	}
 }
 
-func (s *CollaboratorService) Create(opts *v1alpha1.CollaboratorSpec) error {
-	ok, err := s.isOrg(opts.Org)
-	if err != nil {
-		return err
-	}
-	if !ok {
-		return fmt.Errorf("Valid organization required to set collaborators on a repository")
-	}
-	
+// Create adds a collaborator to a repository with the given permission or
+// updates the permission of the collaborator. Users who are not
+// collaborators yet are invited: the invitation is returned, nil otherwise.
+//
+// GitHub API docs: https://docs.github.com/en/rest/collaborators/collaborators?apiVersion=2022-11-28#add-a-repository-collaborator
+func (s *CollaboratorService) Create(opts *v1alpha1.CollaboratorSpec, permission string) (*RepositoryInvitation, error) {
 	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/collaborators/%s", opts.Org, opts.Repo, opts.Username))
 
+	var buf bytes.Buffer
 	githubError := &GithubError{}
 
-	err = requests.URL(s.apiUrl).Path(pt).
+	err := requests.URL(s.apiUrl).Path(pt).
 		Client(s.client).
 		Method(http.MethodPut).
 		Header("Authorization", fmt.Sprintf("token %s", s.token)).
 		BodyJSON(map[string]interface{}{
-			"permission":      opts.Permission,
+			"permission": permission,
 		}).
-		AddValidator(ErrorJSON(githubError, 204)).
+		AddValidator(ErrorJSON(githubError, 201, 204)).
+		ToBytesBuffer(&buf).
 		Fetch(context.Background())
 	if err != nil {
 		var gerr *GithubError
 		if errors.As(err, &gerr) {
-			return fmt.Errorf(gerr.Error())
+			return nil, fmt.Errorf(gerr.Error())
 		}
-		return err
+		return nil, err
 	}
 
-	return nil
+	// 204: the user already is a collaborator.
+	if buf.Len() == 0 {
+		return nil, nil
+	}
+
+	res := &RepositoryInvitation{}
+	if err := json.Unmarshal(buf.Bytes(), res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GrantedPermission returns the permission to grant the collaborator: the
+// desired one on organization-owned repositories, push on user-owned ones.
+func GrantedPermission(opts *v1alpha1.CollaboratorSpec, org bool) string {
+	if !org {
+		return "push"
+	}
+	return opts.Permission
 }
 
 // Check if a user is a collaborator of a repository.
@@ -93,23 +134,23 @@ func (s *CollaboratorService) Exists(opts *v1alpha1.CollaboratorSpec) (bool, err
 // Get repository permissions for a user
 //
 // GitHub API docs: https://docs.github.com/en/rest/collaborators/collaborators?apiVersion=2022-11-28#get-repository-permissions-for-a-user
-func (s *CollaboratorService) GetPermission(opts *v1alpha1.CollaboratorSpec) (string, error) {
+func (s *CollaboratorService) GetPermission(opts *v1alpha1.CollaboratorSpec) (*CollaboratorPermission, error) {
 	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/collaborators/%s/permission", opts.Org, opts.Repo, opts.Username))
 
-	var res CollaboratorPermission 
+	res := &CollaboratorPermission{}
 
 	err := requests.URL(s.apiUrl).Path(pt).
 		Client(s.client).
 		Method(http.MethodGet).
 		Header("Authorization", fmt.Sprintf("token %s", s.token)).
 		CheckStatus(200).
-		ToJSON(&res).
+		ToJSON(res).
 		Fetch(context.Background())
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return res.Permission, nil
+	return res, nil
 }
 
 // https://docs.github.com/en/rest/collaborators/collaborators?apiVersion=2022-11-28#remove-a-repository-collaborator
@@ -133,7 +174,111 @@ func (s *CollaboratorService) Delete(opts *v1alpha1.CollaboratorSpec) error {
 	return nil
 }
 
-func (s *CollaboratorService) isOrg(owner string) (bool, error) {
+// FindInvitation looks up the pending invitation of the user to
+// collaborate on the repository. It returns nil if there is none.
+//
+// GitHub API docs: https://docs.github.com/en/rest/collaborators/invitations#list-repository-invitations
+func (s *CollaboratorService) FindInvitation(opts *v1alpha1.CollaboratorSpec) (*RepositoryInvitation, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/invitations", opts.Org, opts.Repo))
+
+	for page := 1; ; page++ {
+		res := []RepositoryInvitation{}
+
+		err := requests.URL(s.apiUrl).Path(pt).
+			Client(s.client).
+			Method(http.MethodGet).
+			Header("Authorization", fmt.Sprintf("token %s", s.token)).
+			ParamInt("per_page", repositoryInvitationsPerPage).
+			ParamInt("page", page).
+			CheckStatus(200).
+			ToJSON(&res).
+			Fetch(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range res {
+			if res[i].Invitee != nil && strings.EqualFold(res[i].Invitee.Login, opts.Username) {
+				return &res[i], nil
+			}
+		}
+
+		if len(res) < repositoryInvitationsPerPage {
+			return nil, nil
+		}
+	}
+}
+
+// UpdateInvitation changes the permission of a pending invitation. The
+// permission is given as for collaborators: push and pull are translated
+// to write and read.
+//
+// GitHub API docs: https://docs.github.com/en/rest/collaborators/invitations#update-a-repository-invitation
+func (s *CollaboratorService) UpdateInvitation(opts *v1alpha1.CollaboratorSpec, id int64, permission string) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/invitations/%d", opts.Org, opts.Repo, id))
+
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPatch).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(map[string]interface{}{
+			"permissions": InvitationPermission(permission),
+		}).
+		AddValidator(ErrorJSON(githubError, 200)).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return fmt.Errorf(gerr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteInvitation cancels a pending invitation.
+//
+// GitHub API docs: https://docs.github.com/en/rest/collaborators/invitations#delete-a-repository-invitation
+func (s *CollaboratorService) DeleteInvitation(opts *v1alpha1.CollaboratorSpec, id int64) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/invitations/%d", opts.Org, opts.Repo, id))
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodDelete).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(204).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// InvitationPermission translates a collaborator permission to the name
+// used by invitations and by the role of collaborators.
+func InvitationPermission(permission string) string {
+	switch permission {
+	case "push":
+		return "write"
+	case "pull":
+		return "read"
+	default:
+		return permission
+	}
+}
+
+// IsOrg reports whether the owner is an organization rather than a user.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/orgs#get-an-organization
+func (s *CollaboratorService) IsOrg(owner string) (bool, error) {
 	pt := path.Join(s.apiExtraPath, fmt.Sprintf("/orgs/%s", owner))
 	err := requests.URL(s.apiUrl).Path(pt).
 		Client(s.client).
@@ -0,0 +1,37 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/krateoplatformops/github-provider/apis/collaborator/v1alpha1"
+)
+
+func TestInvitationPermission(t *testing.T) {
+	tests := []struct {
+		permission string
+		expected   string
+	}{
+		{permission: "pull", expected: "read"},
+		{permission: "push", expected: "write"},
+		{permission: "triage", expected: "triage"},
+		{permission: "maintain", expected: "maintain"},
+		{permission: "admin", expected: "admin"},
+	}
+
+	for _, tc := range tests {
+		if got := InvitationPermission(tc.permission); got != tc.expected {
+			t.Errorf("InvitationPermission(%q): expected %q, got %q", tc.permission, tc.expected, got)
+		}
+	}
+}
+
+func TestGrantedPermission(t *testing.T) {
+	spec := &v1alpha1.CollaboratorSpec{Org: "acme", Repo: "web", Username: "octocat", Permission: "maintain"}
+
+	if got := GrantedPermission(spec, true); got != "maintain" {
+		t.Errorf("organization: expected %q, got %q", "maintain", got)
+	}
+	if got := GrantedPermission(spec, false); got != "push" {
+		t.Errorf("user: expected %q, got %q", "push", got)
+	}
+}
@@ -0,0 +1,175 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/carlmjohnson/requests"
+	"github.com/krateoplatformops/github-provider/apis/deployKey/v1alpha1"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const deployKeysPerPage = 100
+
+// DeployKeyService provides methods for managing the deploy keys of a repository.
+type DeployKeyService struct {
+	client       *http.Client
+	apiUrl       string
+	apiExtraPath string
+	token        string
+}
+
+// DeployKey represents a deploy key.
+type DeployKey struct {
+	ID       int64  `json:"id"`
+	Key      string `json:"key"`
+	Title    string `json:"title"`
+	ReadOnly bool   `json:"read_only"`
+	Verified bool   `json:"verified"`
+}
+
+// HasKey reports whether the deploy key is the given public key. The
+// comment of the public key, if any, is ignored.
+func (k *DeployKey) HasKey(key string) bool {
+	a, b := strings.Fields(k.Key), strings.Fields(key)
+	if len(a) < 2 || len(b) < 2 {
+		return false
+	}
+	return a[0] == b[0] && a[1] == b[1]
+}
+
+// newDeployKeyService returns a new DeployKeyService.
+func newDeployKeyService(httpClient *http.Client, apiUrl, extraPath, token string) *DeployKeyService {
+	return &DeployKeyService{
+		client:       httpClient,
+		apiUrl:       apiUrl,
+		apiExtraPath: extraPath,
+		token:        token,
+	}
+}
+
+// Find looks up a deploy key of the repository by public key. It returns
+// nil if no such key exists.
+//
+// GitHub API docs: https://docs.github.com/en/rest/deploy-keys/deploy-keys#list-deploy-keys
+func (s *DeployKeyService) Find(opts *v1alpha1.DeployKeySpec, key string) (*DeployKey, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/keys", opts.Owner, opts.Repo))
+
+	for page := 1; ; page++ {
+		res := []DeployKey{}
+
+		err := requests.URL(s.apiUrl).Path(pt).
+			Client(s.client).
+			Method(http.MethodGet).
+			Header("Authorization", fmt.Sprintf("token %s", s.token)).
+			ParamInt("per_page", deployKeysPerPage).
+			ParamInt("page", page).
+			CheckStatus(200).
+			ToJSON(&res).
+			Fetch(context.Background())
+		if err != nil {
+			if requests.HasStatusErr(err, 404) {
+				return nil, nil
+			}
+
+			return nil, err
+		}
+
+		for i := range res {
+			if res[i].HasKey(key) {
+				return &res[i], nil
+			}
+		}
+
+		if len(res) < deployKeysPerPage {
+			return nil, nil
+		}
+	}
+}
+
+// Get fetches a deploy key of the repository. It returns nil if the key does not exist.
+//
+// GitHub API docs: https://docs.github.com/en/rest/deploy-keys/deploy-keys#get-a-deploy-key
+func (s *DeployKeyService) Get(opts *v1alpha1.DeployKeySpec, id int64) (*DeployKey, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/keys/%d", opts.Owner, opts.Repo, id))
+
+	res := &DeployKey{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Create registers a public key as a deploy key of the repository.
+//
+// GitHub API docs: https://docs.github.com/en/rest/deploy-keys/deploy-keys#create-a-deploy-key
+func (s *DeployKeyService) Create(opts *v1alpha1.DeployKeySpec, key string) (*DeployKey, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/keys", opts.Owner, opts.Repo))
+
+	body := map[string]interface{}{
+		"title":     opts.Title,
+		"key":       key,
+		"read_only": ptr.Deref(opts.ReadOnly, true),
+	}
+
+	res := &DeployKey{}
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPost).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, 201)).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return nil, fmt.Errorf(gerr.Error())
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Delete removes a deploy key from the repository.
+//
+// GitHub API docs: https://docs.github.com/en/rest/deploy-keys/deploy-keys#delete-a-deploy-key
+func (s *DeployKeyService) Delete(opts *v1alpha1.DeployKeySpec, id int64) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/keys/%d", opts.Owner, opts.Repo, id))
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodDelete).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(204).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
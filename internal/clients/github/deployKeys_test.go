@@ -0,0 +1,27 @@
+package github
+
+import "testing"
+
+func TestDeployKeyHasKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		observed string
+		key      string
+		expected bool
+	}{
+		{name: "same key", observed: "ssh-ed25519 AAAAC3Nza", key: "ssh-ed25519 AAAAC3Nza", expected: true},
+		{name: "comment ignored", observed: "ssh-ed25519 AAAAC3Nza", key: "ssh-ed25519 AAAAC3Nza deploy@acme", expected: true},
+		{name: "other key", observed: "ssh-ed25519 AAAAC3Nza", key: "ssh-ed25519 AAAAB3Nza", expected: false},
+		{name: "other type", observed: "ssh-rsa AAAAC3Nza", key: "ssh-ed25519 AAAAC3Nza", expected: false},
+		{name: "malformed", observed: "ssh-ed25519 AAAAC3Nza", key: "AAAAC3Nza", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k := &DeployKey{Key: tc.observed}
+			if got := k.HasKey(tc.key); got != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package github
+
+import (
+	"fmt"
+	"slices"
+)
+
+// DiffValue appends a description of the difference to diff when the
+// desired value does not match the observed one.
+func DiffValue[T comparable](diff []string, field string, want, got T) []string {
+	if want == got {
+		return diff
+	}
+	return append(diff, fmt.Sprintf("%s: %v (observed: %v)", field, want, got))
+}
+
+// DiffDeclared is like DiffValue for optional fields: the observed value
+// is ignored when the desired one is not declared.
+func DiffDeclared[T comparable](diff []string, field string, want *T, got T) []string {
+	if want == nil {
+		return diff
+	}
+	return DiffValue(diff, field, *want, got)
+}
+
+// DiffItems appends a description of the difference to diff when the
+// lists do not hold the same items, regardless of their order.
+func DiffItems(diff []string, field string, want, got []string) []string {
+	if SameItems(want, got) {
+		return diff
+	}
+	return append(diff, fmt.Sprintf("%s: %v (observed: %v)", field, want, got))
+}
+
+// SameItems reports whether both lists hold the same items, regardless of
+// their order and of duplicates.
+func SameItems(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
@@ -0,0 +1,97 @@
+package github
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+func TestDiffValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		want, got string
+		expected  []string
+	}{
+		{name: "equal", want: "main", got: "main", expected: nil},
+		{name: "different", want: "main", got: "master", expected: []string{"branch: main (observed: master)"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := DiffValue(nil, "branch", tc.want, tc.got)
+			if !slices.Equal(diff, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestDiffDeclared(t *testing.T) {
+	tests := []struct {
+		name     string
+		want     *bool
+		got      bool
+		expected []string
+	}{
+		{name: "not declared", want: nil, got: true, expected: nil},
+		{name: "equal", want: ptr.To(true), got: true, expected: nil},
+		{name: "different", want: ptr.To(false), got: true, expected: []string{"archived: false (observed: true)"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := DiffDeclared(nil, "archived", tc.want, tc.got)
+			if !slices.Equal(diff, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestDiffItems(t *testing.T) {
+	tests := []struct {
+		name      string
+		want, got []string
+		expected  []string
+	}{
+		{name: "both empty", want: nil, got: []string{}, expected: nil},
+		{name: "other order", want: []string{"a", "b"}, got: []string{"b", "a"}, expected: nil},
+		{name: "missing item", want: []string{"a", "b"}, got: []string{"a"}, expected: []string{"topics: [a b] (observed: [a])"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := DiffItems(nil, "topics", tc.want, tc.got)
+			if !slices.Equal(diff, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestSameItems(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     []string
+		expected bool
+	}{
+		{name: "nil and empty", a: nil, b: []string{}, expected: true},
+		{name: "other order", a: []string{"a", "b", "c"}, b: []string{"c", "a", "b"}, expected: true},
+		{name: "duplicates", a: []string{"a", "a", "b"}, b: []string{"b", "a"}, expected: true},
+		{name: "different", a: []string{"a", "b"}, b: []string{"a", "c"}, expected: false},
+		{name: "subset", a: []string{"a"}, b: []string{"a", "b"}, expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a, b := slices.Clone(tc.a), slices.Clone(tc.b)
+			if got := SameItems(tc.a, tc.b); got != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+			if !slices.Equal(a, tc.a) || !slices.Equal(b, tc.b) {
+				t.Fatalf("the lists were modified")
+			}
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/carlmjohnson/requests"
+	"github.com/krateoplatformops/github-provider/apis/environment/v1alpha1"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const branchPoliciesPerPage = 100
+
+// EnvironmentService provides methods for managing the deployment
+// environments of a repository.
+type EnvironmentService struct {
+	client       *http.Client
+	apiUrl       string
+	apiExtraPath string
+	token        string
+}
+
+// Environment represents a deployment environment.
+type Environment struct {
+	ID                     int64                              `json:"id"`
+	Name                   string                             `json:"name"`
+	ProtectionRules        []EnvironmentProtectionRule        `json:"protection_rules"`
+	DeploymentBranchPolicy *EnvironmentDeploymentBranchPolicy `json:"deployment_branch_policy"`
+}
+
+// EnvironmentProtectionRule is a protection rule of an environment. Only
+// the fields of its type are set.
+type EnvironmentProtectionRule struct {
+	ID                int64                 `json:"id"`
+	Type              string                `json:"type"`
+	WaitTimer         *int                  `json:"wait_timer,omitempty"`
+	PreventSelfReview *bool                 `json:"prevent_self_review,omitempty"`
+	Reviewers         []EnvironmentReviewer `json:"reviewers,omitempty"`
+}
+
+// EnvironmentReviewer is a user or a team that can approve deployments.
+type EnvironmentReviewer struct {
+	Type     string `json:"type"`
+	Reviewer struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login,omitempty"`
+		Slug  string `json:"slug,omitempty"`
+	} `json:"reviewer"`
+}
+
+// EnvironmentDeploymentBranchPolicy tells which branches can deploy to an
+// environment.
+type EnvironmentDeploymentBranchPolicy struct {
+	ProtectedBranches    bool `json:"protected_branches"`
+	CustomBranchPolicies bool `json:"custom_branch_policies"`
+}
+
+// ReviewerID identifies a reviewer in a request body.
+type ReviewerID struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id"`
+}
+
+// BranchPolicy represents a deployment branch or tag policy.
+type BranchPolicy struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// WaitTimer returns the minutes deployments wait before proceeding.
+func (env *Environment) WaitTimer() int {
+	for _, r := range env.ProtectionRules {
+		if r.Type == "wait_timer" {
+			return ptr.Deref(r.WaitTimer, 0)
+		}
+	}
+	return 0
+}
+
+// requiredReviewers returns the required reviewers rule, or nil.
+func (env *Environment) requiredReviewers() *EnvironmentProtectionRule {
+	for i := range env.ProtectionRules {
+		if env.ProtectionRules[i].Type == "required_reviewers" {
+			return &env.ProtectionRules[i]
+		}
+	}
+	return nil
+}
+
+// Diff returns the differences between the environment and the desired
+// spec. The branch policies are compared by DiffBranchPolicies.
+func (env *Environment) Diff(spec *v1alpha1.EnvironmentSpec) []string {
+	diff := DiffValue(nil, "waitTimer", ptr.Deref(spec.WaitTimer, 0), env.WaitTimer())
+
+	reviewers, preventSelfReview := []string{}, false
+	if r := env.requiredReviewers(); r != nil {
+		for _, rv := range r.Reviewers {
+			reviewers = append(reviewers, reviewerKey(rv.Type, rv.Reviewer.Login+rv.Reviewer.Slug))
+		}
+		preventSelfReview = ptr.Deref(r.PreventSelfReview, false)
+	}
+	want := []string{}
+	for _, rv := range spec.Reviewers {
+		want = append(want, reviewerKey(rv.Type, rv.Name))
+	}
+	diff = DiffItems(diff, "reviewers", want, reviewers)
+	diff = DiffValue(diff, "preventSelfReview", ptr.Deref(spec.PreventSelfReview, false), preventSelfReview)
+
+	policy := ""
+	if p := env.DeploymentBranchPolicy; p != nil {
+		switch {
+		case p.ProtectedBranches:
+			policy = v1alpha1.DeploymentBranchPolicyProtectedBranches
+		case p.CustomBranchPolicies:
+			policy = v1alpha1.DeploymentBranchPolicyCustomBranchPolicies
+		}
+	}
+	diff = DiffValue(diff, "deploymentBranchPolicy", ptr.Deref(spec.DeploymentBranchPolicy, ""), policy)
+
+	return diff
+}
+
+// DiffBranchPolicies returns the differences between the branch policies
+// of an environment and the desired ones.
+func DiffBranchPolicies(want []v1alpha1.BranchPolicy, got []BranchPolicy) []string {
+	a, b := []string{}, []string{}
+	for _, p := range want {
+		a = append(a, BranchPolicyKey(ptr.Deref(p.Type, ""), p.Name))
+	}
+	for _, p := range got {
+		b = append(b, BranchPolicyKey(p.Type, p.Name))
+	}
+	return DiffItems(nil, "branchPolicies", a, b)
+}
+
+func reviewerKey(typ, name string) string {
+	return fmt.Sprintf("%s:%s", typ, strings.ToLower(name))
+}
+
+// BranchPolicyKey identifies a branch policy by its type and name. An
+// empty type stands for branch, the default.
+func BranchPolicyKey(typ, name string) string {
+	if len(typ) == 0 {
+		typ = "branch"
+	}
+	return fmt.Sprintf("%s:%s", typ, name)
+}
+
+// newEnvironmentService returns a new EnvironmentService.
+func newEnvironmentService(httpClient *http.Client, apiUrl, extraPath, token string) *EnvironmentService {
+	return &EnvironmentService{
+		client:       httpClient,
+		apiUrl:       apiUrl,
+		apiExtraPath: extraPath,
+		token:        token,
+	}
+}
+
+// Get fetches an environment. It returns nil if the environment does not exist.
+//
+// GitHub API docs: https://docs.github.com/en/rest/deployments/environments#get-an-environment
+func (s *EnvironmentService) Get(opts *v1alpha1.EnvironmentSpec) (*Environment, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/environments/%s", opts.Owner, opts.Repo, opts.Name))
+
+	res := &Environment{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Put creates or updates an environment with the given reviewers. All the
+// protection rules are replaced.
+//
+// GitHub API docs: https://docs.github.com/en/rest/deployments/environments#create-or-update-an-environment
+func (s *EnvironmentService) Put(opts *v1alpha1.EnvironmentSpec, reviewers []ReviewerID) (*Environment, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/environments/%s", opts.Owner, opts.Repo, opts.Name))
+
+	if reviewers == nil {
+		reviewers = []ReviewerID{}
+	}
+
+	body := map[string]interface{}{
+		"wait_timer":               ptr.Deref(opts.WaitTimer, 0),
+		"prevent_self_review":      ptr.Deref(opts.PreventSelfReview, false),
+		"reviewers":                reviewers,
+		"deployment_branch_policy": nil,
+	}
+	switch ptr.Deref(opts.DeploymentBranchPolicy, "") {
+	case v1alpha1.DeploymentBranchPolicyProtectedBranches:
+		body["deployment_branch_policy"] = EnvironmentDeploymentBranchPolicy{ProtectedBranches: true}
+	case v1alpha1.DeploymentBranchPolicyCustomBranchPolicies:
+		body["deployment_branch_policy"] = EnvironmentDeploymentBranchPolicy{CustomBranchPolicies: true}
+	}
+
+	res := &Environment{}
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPut).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, 200)).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return nil, fmt.Errorf(gerr.Error())
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Delete deletes an environment.
+//
+// GitHub API docs: https://docs.github.com/en/rest/deployments/environments#delete-an-environment
+func (s *EnvironmentService) Delete(opts *v1alpha1.EnvironmentSpec) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/environments/%s", opts.Owner, opts.Repo, opts.Name))
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodDelete).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(204).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// ListBranchPolicies lists the deployment branch and tag policies of an environment.
+//
+// GitHub API docs: https://docs.github.com/en/rest/deployments/branch-policies#list-deployment-branch-policies
+func (s *EnvironmentService) ListBranchPolicies(opts *v1alpha1.EnvironmentSpec) ([]BranchPolicy, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/environments/%s/deployment-branch-policies", opts.Owner, opts.Repo, opts.Name))
+
+	policies := []BranchPolicy{}
+	for page := 1; ; page++ {
+		res := struct {
+			BranchPolicies []BranchPolicy `json:"branch_policies"`
+		}{}
+
+		err := requests.URL(s.apiUrl).Path(pt).
+			Client(s.client).
+			Method(http.MethodGet).
+			Header("Authorization", fmt.Sprintf("token %s", s.token)).
+			ParamInt("per_page", branchPoliciesPerPage).
+			ParamInt("page", page).
+			CheckStatus(200).
+			ToJSON(&res).
+			Fetch(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		policies = append(policies, res.BranchPolicies...)
+
+		if len(res.BranchPolicies) < branchPoliciesPerPage {
+			return policies, nil
+		}
+	}
+}
+
+// CreateBranchPolicy adds a deployment branch or tag policy to an environment.
+//
+// GitHub API docs: https://docs.github.com/en/rest/deployments/branch-policies#create-a-deployment-branch-policy
+func (s *EnvironmentService) CreateBranchPolicy(opts *v1alpha1.EnvironmentSpec, policy *v1alpha1.BranchPolicy) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/environments/%s/deployment-branch-policies", opts.Owner, opts.Repo, opts.Name))
+
+	body := map[string]interface{}{
+		"name": policy.Name,
+		"type": ptr.Deref(policy.Type, "branch"),
+	}
+
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPost).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, 200)).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return fmt.Errorf(gerr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteBranchPolicy removes a deployment branch or tag policy from an environment.
+//
+// GitHub API docs: https://docs.github.com/en/rest/deployments/branch-policies#delete-a-deployment-branch-policy
+func (s *EnvironmentService) DeleteBranchPolicy(opts *v1alpha1.EnvironmentSpec, id int64) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/environments/%s/deployment-branch-policies/%d", opts.Owner, opts.Repo, opts.Name, id))
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodDelete).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(204).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package github
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/krateoplatformops/github-provider/apis/environment/v1alpha1"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+func TestEnvironmentDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     v1alpha1.EnvironmentSpec
+		observed string
+		expected []string
+	}{
+		{
+			name:     "no protection",
+			spec:     v1alpha1.EnvironmentSpec{Name: "production"},
+			observed: `{"name": "production"}`,
+			expected: nil,
+		},
+		{
+			name: "up to date",
+			spec: v1alpha1.EnvironmentSpec{
+				Name:      "production",
+				WaitTimer: ptr.To(30),
+				Reviewers: []v1alpha1.Reviewer{
+					{Type: v1alpha1.ReviewerTypeTeam, Name: "ops"},
+					{Type: v1alpha1.ReviewerTypeUser, Name: "Octocat"},
+				},
+				PreventSelfReview:      ptr.To(true),
+				DeploymentBranchPolicy: ptr.To(v1alpha1.DeploymentBranchPolicyCustomBranchPolicies),
+			},
+			observed: `{
+				"name": "production",
+				"protection_rules": [
+					{"type": "wait_timer", "wait_timer": 30},
+					{"type": "required_reviewers", "prevent_self_review": true, "reviewers": [
+						{"type": "User", "reviewer": {"login": "octocat"}},
+						{"type": "Team", "reviewer": {"slug": "ops"}}
+					]}
+				],
+				"deployment_branch_policy": {"protected_branches": false, "custom_branch_policies": true}
+			}`,
+			expected: nil,
+		},
+		{
+			name:     "protection removed",
+			spec:     v1alpha1.EnvironmentSpec{Name: "production"},
+			observed: `{"name": "production", "protection_rules": [{"type": "wait_timer", "wait_timer": 5}], "deployment_branch_policy": {"protected_branches": true}}`,
+			expected: []string{"waitTimer: 0 (observed: 5)", "deploymentBranchPolicy:  (observed: protectedBranches)"},
+		},
+		{
+			name: "missing reviewer",
+			spec: v1alpha1.EnvironmentSpec{
+				Name:      "production",
+				Reviewers: []v1alpha1.Reviewer{{Type: v1alpha1.ReviewerTypeUser, Name: "octocat"}},
+			},
+			observed: `{"name": "production"}`,
+			expected: []string{"reviewers: [User:octocat] (observed: [])"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			env := &Environment{}
+			if err := json.Unmarshal([]byte(tc.observed), env); err != nil {
+				t.Fatal(err)
+			}
+
+			diff := env.Diff(&tc.spec)
+			if !slices.Equal(diff, tc.expected) {
+				t.Fatalf("expected %q, got %q", tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestDiffBranchPolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		want     []v1alpha1.BranchPolicy
+		got      []BranchPolicy
+		expected []string
+	}{
+		{
+			name:     "none",
+			expected: nil,
+		},
+		{
+			name: "branch is the default type",
+			want: []v1alpha1.BranchPolicy{{Name: "main"}, {Name: "v*", Type: ptr.To("tag")}},
+			got: []BranchPolicy{
+				{ID: 2, Name: "v*", Type: "tag"},
+				{ID: 1, Name: "main", Type: "branch"},
+			},
+			expected: nil,
+		},
+		{
+			name:     "same name of another type",
+			want:     []v1alpha1.BranchPolicy{{Name: "v1"}},
+			got:      []BranchPolicy{{ID: 1, Name: "v1", Type: "tag"}},
+			expected: []string{"branchPolicies: [branch:v1] (observed: [tag:v1])"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := DiffBranchPolicies(tc.want, tc.got)
+			if !slices.Equal(diff, tc.expected) {
+				t.Fatalf("expected %q, got %q", tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestBranchPolicyKey(t *testing.T) {
+	tests := []struct {
+		typ, name string
+		expected  string
+	}{
+		{typ: "", name: "main", expected: "branch:main"},
+		{typ: "branch", name: "main", expected: "branch:main"},
+		{typ: "tag", name: "v*", expected: "tag:v*"},
+	}
+
+	for _, tc := range tests {
+		if got := BranchPolicyKey(tc.typ, tc.name); got != tc.expected {
+			t.Errorf("BranchPolicyKey(%q, %q): expected %q, got %q", tc.typ, tc.name, tc.expected, got)
+		}
+	}
+}
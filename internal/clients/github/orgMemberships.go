@@ -0,0 +1,284 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/carlmjohnson/requests"
+	"github.com/krateoplatformops/github-provider/apis/orgMembership/v1alpha1"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const (
+	invitationsPerPage = 100
+
+	// invitationMemberRole is the name of the member role in invitations.
+	invitationMemberRole = "direct_member"
+)
+
+// OrgMembershipService provides methods for managing the members of an
+// organization and the invitations to join it.
+type OrgMembershipService struct {
+	client       *http.Client
+	apiUrl       string
+	apiExtraPath string
+	token        string
+}
+
+// OrgMembership represents the membership of a user in an organization.
+type OrgMembership struct {
+	Role  string `json:"role"`
+	State string `json:"state"`
+}
+
+// Invitation represents an invitation to join an organization.
+type Invitation struct {
+	ID           int64      `json:"id"`
+	Login        string     `json:"login"`
+	Email        string     `json:"email"`
+	Role         string     `json:"role"`
+	CreatedAt    time.Time  `json:"created_at"`
+	FailedAt     *time.Time `json:"failed_at"`
+	FailedReason string     `json:"failed_reason"`
+}
+
+// Expired reports whether the invitation failed because it expired.
+func (inv *Invitation) Expired() bool {
+	return inv.FailedAt != nil && strings.Contains(strings.ToLower(inv.FailedReason), "expired")
+}
+
+// OrgRole returns the role the invitation grants, as named by the spec:
+// invitations call the member role direct_member.
+func (inv *Invitation) OrgRole() string {
+	if inv.Role == invitationMemberRole {
+		return v1alpha1.RoleMember
+	}
+	return inv.Role
+}
+
+// invites reports whether the invitation is for the user or the email
+// address of the spec.
+func (inv *Invitation) invites(opts *v1alpha1.OrgMembershipSpec) bool {
+	if opts.Username != nil {
+		return strings.EqualFold(inv.Login, *opts.Username)
+	}
+	return strings.EqualFold(inv.Email, ptr.Deref(opts.Email, ""))
+}
+
+// newOrgMembershipService returns a new OrgMembershipService.
+func newOrgMembershipService(httpClient *http.Client, apiUrl, extraPath, token string) *OrgMembershipService {
+	return &OrgMembershipService{
+		client:       httpClient,
+		apiUrl:       apiUrl,
+		apiExtraPath: extraPath,
+		token:        token,
+	}
+}
+
+// Get fetches the membership of a user in the organization. It returns nil
+// if the user is neither a member nor invited.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/members#get-organization-membership-for-a-user
+func (s *OrgMembershipService) Get(org, username string) (*OrgMembership, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("orgs/%s/memberships/%s", org, username))
+
+	res := &OrgMembership{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetRole changes the role of a member of the organization. Only the admin
+// and member roles can be set.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/members#set-organization-membership-for-a-user
+func (s *OrgMembershipService) SetRole(org, username, role string) (*OrgMembership, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("orgs/%s/memberships/%s", org, username))
+
+	body := map[string]interface{}{
+		"role": role,
+	}
+
+	res := &OrgMembership{}
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPut).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, 200)).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return nil, fmt.Errorf(gerr.Error())
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Remove removes a user from the organization, or cancels the pending
+// invitation of the user.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/members#remove-organization-membership-for-a-user
+func (s *OrgMembershipService) Remove(org, username string) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("orgs/%s/memberships/%s", org, username))
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodDelete).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(204).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// FindInvitation looks up the pending invitation of the user or the email
+// address of the spec. It returns nil if there is none.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/members#list-pending-organization-invitations
+func (s *OrgMembershipService) FindInvitation(opts *v1alpha1.OrgMembershipSpec) (*Invitation, error) {
+	return s.findInvitation(fmt.Sprintf("orgs/%s/invitations", opts.Org), opts)
+}
+
+// FindFailedInvitation looks up the latest failed invitation of the user
+// or the email address of the spec. It returns nil if there is none.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/members#list-failed-organization-invitations
+func (s *OrgMembershipService) FindFailedInvitation(opts *v1alpha1.OrgMembershipSpec) (*Invitation, error) {
+	return s.findInvitation(fmt.Sprintf("orgs/%s/failed_invitations", opts.Org), opts)
+}
+
+// Invite invites the user or the email address of the spec to join the
+// organization. The user is given by id.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/members#create-an-organization-invitation
+func (s *OrgMembershipService) Invite(opts *v1alpha1.OrgMembershipSpec, userID int64) (*Invitation, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("orgs/%s/invitations", opts.Org))
+
+	role := ptr.Deref(opts.Role, v1alpha1.RoleMember)
+	if role == v1alpha1.RoleMember {
+		role = invitationMemberRole
+	}
+
+	body := map[string]interface{}{
+		"role": role,
+	}
+	if userID != 0 {
+		body["invitee_id"] = userID
+	} else {
+		body["email"] = ptr.Deref(opts.Email, "")
+	}
+	if len(opts.TeamIds) > 0 {
+		body["team_ids"] = opts.TeamIds
+	}
+
+	res := &Invitation{}
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPost).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, 201)).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return nil, fmt.Errorf(gerr.Error())
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// CancelInvitation cancels a pending invitation.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/members#cancel-an-organization-invitation
+func (s *OrgMembershipService) CancelInvitation(org string, id int64) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("orgs/%s/invitations/%d", org, id))
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodDelete).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(204).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// findInvitation pages through a list of invitations and returns the
+// latest one matching the spec.
+func (s *OrgMembershipService) findInvitation(pt string, opts *v1alpha1.OrgMembershipSpec) (*Invitation, error) {
+	pt = path.Join(s.apiExtraPath, pt)
+
+	var found *Invitation
+	for page := 1; ; page++ {
+		res := []Invitation{}
+
+		err := requests.URL(s.apiUrl).Path(pt).
+			Client(s.client).
+			Method(http.MethodGet).
+			Header("Authorization", fmt.Sprintf("token %s", s.token)).
+			ParamInt("per_page", invitationsPerPage).
+			ParamInt("page", page).
+			CheckStatus(200).
+			ToJSON(&res).
+			Fetch(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range res {
+			if res[i].invites(opts) && (found == nil || res[i].ID > found.ID) {
+				found = &res[i]
+			}
+		}
+
+		if len(res) < invitationsPerPage {
+			return found, nil
+		}
+	}
+}
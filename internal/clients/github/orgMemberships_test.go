@@ -0,0 +1,92 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krateoplatformops/github-provider/apis/orgMembership/v1alpha1"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+func TestInvitationExpired(t *testing.T) {
+	failedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		invitation Invitation
+		expected   bool
+	}{
+		{name: "pending", invitation: Invitation{}},
+		{name: "expired", invitation: Invitation{FailedAt: &failedAt, FailedReason: "Invitation expired"}, expected: true},
+		{name: "cancelled", invitation: Invitation{FailedAt: &failedAt, FailedReason: "Invitation cancelled"}},
+		{name: "reason without failure", invitation: Invitation{FailedReason: "expired"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.invitation.Expired(); got != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestInvitationOrgRole(t *testing.T) {
+	tests := []struct {
+		role     string
+		expected string
+	}{
+		{role: "direct_member", expected: v1alpha1.RoleMember},
+		{role: "admin", expected: v1alpha1.RoleAdmin},
+		{role: "billing_manager", expected: v1alpha1.RoleBillingManager},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.role, func(t *testing.T) {
+			inv := &Invitation{Role: tc.role}
+			if got := inv.OrgRole(); got != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestInvitationInvites(t *testing.T) {
+	tests := []struct {
+		name       string
+		invitation Invitation
+		spec       v1alpha1.OrgMembershipSpec
+		expected   bool
+	}{
+		{
+			name:       "same login",
+			invitation: Invitation{Login: "Octocat"},
+			spec:       v1alpha1.OrgMembershipSpec{Username: ptr.To("octocat")},
+			expected:   true,
+		},
+		{
+			name:       "other login",
+			invitation: Invitation{Login: "hubot"},
+			spec:       v1alpha1.OrgMembershipSpec{Username: ptr.To("octocat")},
+		},
+		{
+			name:       "same email",
+			invitation: Invitation{Email: "Octocat@example.com"},
+			spec:       v1alpha1.OrgMembershipSpec{Email: ptr.To("octocat@example.com")},
+			expected:   true,
+		},
+		{
+			name:       "email of a user invitation",
+			invitation: Invitation{Login: "octocat"},
+			spec:       v1alpha1.OrgMembershipSpec{Email: ptr.To("octocat@example.com")},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.invitation.invites(&tc.spec); got != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"path"
+	"regexp"
+	"time"
 
 	"github.com/carlmjohnson/requests"
 	"github.com/krateoplatformops/github-provider/apis/repo/v1alpha1"
@@ -20,6 +22,77 @@ type RepoService struct {
 	token        string
 }
 
+// Repository represents a GitHub repository.
+type Repository struct {
+	ID                       int64                `json:"id"`
+	NodeID                   string               `json:"node_id"`
+	Name                     string               `json:"name"`
+	FullName                 string               `json:"full_name"`
+	Owner                    RepositoryOwner      `json:"owner"`
+	HtmlURL                  string               `json:"html_url"`
+	CloneURL                 string               `json:"clone_url"`
+	SshURL                   string               `json:"ssh_url"`
+	Private                  bool                 `json:"private"`
+	Visibility               string               `json:"visibility"`
+	Archived                 bool                 `json:"archived"`
+	Description              string               `json:"description"`
+	Homepage                 string               `json:"homepage"`
+	DefaultBranch            string               `json:"default_branch"`
+	HasIssues                bool                 `json:"has_issues"`
+	HasProjects              bool                 `json:"has_projects"`
+	HasWiki                  bool                 `json:"has_wiki"`
+	HasDiscussions           bool                 `json:"has_discussions"`
+	AllowSquashMerge         bool                 `json:"allow_squash_merge"`
+	AllowMergeCommit         bool                 `json:"allow_merge_commit"`
+	AllowRebaseMerge         bool                 `json:"allow_rebase_merge"`
+	AllowAutoMerge           bool                 `json:"allow_auto_merge"`
+	DeleteBranchOnMerge      bool                 `json:"delete_branch_on_merge"`
+	SquashMergeCommitTitle   string               `json:"squash_merge_commit_title"`
+	SquashMergeCommitMessage string               `json:"squash_merge_commit_message"`
+	MergeCommitTitle         string               `json:"merge_commit_title"`
+	MergeCommitMessage       string               `json:"merge_commit_message"`
+	IsTemplate               bool                 `json:"is_template"`
+	Size                     int64                `json:"size"`
+	CreatedAt                *time.Time           `json:"created_at"`
+	PushedAt                 *time.Time           `json:"pushed_at"`
+	Fork                     bool                 `json:"fork"`
+	SecurityAndAnalysis      *SecurityAndAnalysis `json:"security_and_analysis"`
+	TemplateRepository       *RepositoryRef       `json:"template_repository"`
+	Parent                   *RepositoryRef       `json:"parent"`
+	Source                   *RepositoryRef       `json:"source"`
+}
+
+// SecurityAndAnalysis reports the status of the security and analysis
+// features of a repository. It is only returned to repository admins.
+type SecurityAndAnalysis struct {
+	AdvancedSecurity             *SecurityFeature `json:"advanced_security"`
+	SecretScanning               *SecurityFeature `json:"secret_scanning"`
+	SecretScanningPushProtection *SecurityFeature `json:"secret_scanning_push_protection"`
+	SecretScanningValidityChecks *SecurityFeature `json:"secret_scanning_validity_checks"`
+}
+
+// SecurityFeature is the status of a security and analysis feature.
+type SecurityFeature struct {
+	Status string `json:"status"`
+}
+
+// Enabled reports whether the feature is enabled.
+func (f *SecurityFeature) Enabled() bool {
+	return f != nil && f.Status == "enabled"
+}
+
+// RepositoryOwner is the account that owns a repository.
+type RepositoryOwner struct {
+	Login string `json:"login"`
+	Type  string `json:"type"`
+}
+
+// RepositoryRef is the short form of a repository nested in another
+// repository object.
+type RepositoryRef struct {
+	FullName string `json:"full_name"`
+}
+
 // newRepoService returns a new RepoService.
 func newRepoService(httpClient *http.Client, apiUrl, extraPath, token string) *RepoService {
 	return &RepoService{
@@ -30,10 +103,23 @@ func newRepoService(httpClient *http.Client, apiUrl, extraPath, token string) *R
 	}
 }
 
-func (s *RepoService) Create(opts *v1alpha1.RepoSpec) error {
+// Create creates a repository and returns it.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/repos#create-an-organization-repository
+func (s *RepoService) Create(opts *v1alpha1.RepoSpec) (*Repository, error) {
+	if opts.TemplateRef != nil && opts.Fork != nil {
+		return nil, fmt.Errorf("templateRef and fork cannot be set at the same time")
+	}
+	if opts.TemplateRef != nil {
+		return s.generate(opts)
+	}
+	if opts.Fork != nil {
+		return s.fork(opts)
+	}
+
 	ok, err := s.isOrg(opts.Org)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	pt := path.Join(s.apiExtraPath, "/user/repos")
@@ -41,16 +127,177 @@ func (s *RepoService) Create(opts *v1alpha1.RepoSpec) error {
 		pt = path.Join(s.apiExtraPath, fmt.Sprintf("orgs/%s/repos", opts.Org))
 	}
 
+	res := &Repository{}
+	githubError := &GithubError{}
+
+	err = requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPost).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(createBody(opts)).
+		AddValidator(ErrorJSON(githubError, 201)).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return nil, fmt.Errorf(gerr.Error())
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// generate creates a repository using a template repository.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/repos#create-a-repository-using-a-template
+func (s *RepoService) generate(opts *v1alpha1.RepoSpec) (*Repository, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/generate", opts.TemplateRef.Owner, opts.TemplateRef.Name))
+
+	private := opts.Private
+	if opts.Visibility != nil {
+		private = *opts.Visibility != "public"
+	}
+
+	body := map[string]interface{}{
+		"owner":                opts.Org,
+		"name":                 opts.Name,
+		"private":              private,
+		"include_all_branches": ptr.Deref(opts.TemplateRef.IncludeAllBranches, false),
+	}
+	setIfNotNil(body, "description", opts.Description)
+
+	res := &Repository{}
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPost).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, 201)).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return nil, fmt.Errorf(gerr.Error())
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// fork requests the creation of a fork of the upstream repository.
+// Forking happens asynchronously: the repository may not be accessible
+// right away. Asking again for a fork that is still being created returns
+// the pending fork, so the request is safe to repeat.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/forks#create-a-fork
+func (s *RepoService) fork(opts *v1alpha1.RepoSpec) (*Repository, error) {
+	ok, err := s.isOrg(opts.Org)
+	if err != nil {
+		return nil, err
+	}
+
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/forks", opts.Fork.Owner, opts.Fork.Repo))
+
+	body := map[string]interface{}{
+		"name":                opts.Name,
+		"default_branch_only": ptr.Deref(opts.Fork.DefaultBranchOnly, false),
+	}
+	if ok {
+		body["organization"] = opts.Org
+	}
+
+	res := &Repository{}
 	githubError := &GithubError{}
 
 	err = requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPost).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, 202)).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return nil, fmt.Errorf(gerr.Error())
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// BranchExists checks whether a branch exists in the repository.
+//
+// GitHub API docs: https://docs.github.com/en/rest/branches/branches#get-a-branch
+func (s *RepoService) BranchExists(opts *v1alpha1.RepoSpec, branch string) (bool, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/branches/%s", opts.Org, opts.Name, branch))
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// HasBranches checks whether the repository has at least one branch, that
+// is whether anything has been pushed to it yet.
+//
+// GitHub API docs: https://docs.github.com/en/rest/branches/branches#list-branches
+func (s *RepoService) HasBranches(opts *v1alpha1.RepoSpec) (bool, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/branches", opts.Org, opts.Name))
+
+	res := []struct {
+		Name string `json:"name"`
+	}{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		Param("per_page", "1").
+		CheckStatus(200).
+		ToJSON(&res).
+		Fetch(context.Background())
+	if err != nil {
+		return false, err
+	}
+
+	return len(res) > 0, nil
+}
+
+// RenameBranch renames a branch. Renaming the default branch keeps it as
+// the default branch under its new name.
+//
+// GitHub API docs: https://docs.github.com/en/rest/branches/branches#rename-a-branch
+func (s *RepoService) RenameBranch(opts *v1alpha1.RepoSpec, branch, newName string) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/branches/%s/rename", opts.Org, opts.Name, branch))
+
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
 		Client(s.client).
 		Method(http.MethodPost).
 		Header("Authorization", fmt.Sprintf("token %s", s.token)).
 		BodyJSON(map[string]interface{}{
-			"name":      opts.Name,
-			"private":   opts.Private,
-			"auto_init": ptr.Deref(opts.Initialize, true),
+			"new_name": newName,
 		}).
 		AddValidator(ErrorJSON(githubError, 201)).
 		Fetch(context.Background())
@@ -65,17 +312,206 @@ func (s *RepoService) Create(opts *v1alpha1.RepoSpec) error {
 	return nil
 }
 
-// Get fetches a repository.
+// Get fetches a repository. It returns nil if the repository does not exist.
 //
 // GitHub API docs: https://docs.github.com/en/free-pro-team@latest/rest/reference/repos/#get-a-repository
-func (s *RepoService) Exists(opts *v1alpha1.RepoSpec) (bool, error) {
+func (s *RepoService) Get(opts *v1alpha1.RepoSpec) (*Repository, error) {
 	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s", opts.Org, opts.Name))
 
+	res := &Repository{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetByID fetches a repository by its numeric identifier, which does not
+// change when the repository is renamed or transferred. It returns nil if
+// the repository does not exist.
+func (s *RepoService) GetByID(id int64) (*Repository, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repositories/%d", id))
+
+	res := &Repository{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// IDs resolves the names of repositories of the organization to their ids.
+func (s *RepoService) IDs(org string, names []string) ([]int64, error) {
+	ids := make([]int64, 0, len(names))
+	for _, name := range names {
+		repo, err := s.Get(&v1alpha1.RepoSpec{Org: org, Name: name})
+		if err != nil {
+			return nil, err
+		}
+		if repo == nil {
+			return nil, fmt.Errorf("repository '%s/%s' not found", org, name)
+		}
+		ids = append(ids, repo.ID)
+	}
+	return ids, nil
+}
+
+// Rename changes the name of a repository.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/repos#update-a-repository
+func (s *RepoService) Rename(opts *v1alpha1.RepoSpec, name string) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s", opts.Org, opts.Name))
+
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPatch).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(map[string]interface{}{
+			"name": name,
+		}).
+		AddValidator(ErrorJSON(githubError, 200)).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return fmt.Errorf(gerr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Transfer starts the transfer of a repository to another user or
+// organization, optionally renaming it. The transfer completes
+// asynchronously, and transfers to a user must be accepted by that user.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/repos#transfer-a-repository
+func (s *RepoService) Transfer(opts *v1alpha1.RepoSpec, newOwner, newName string) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/transfer", opts.Org, opts.Name))
+
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPost).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(map[string]interface{}{
+			"new_owner": newOwner,
+			"new_name":  newName,
+		}).
+		AddValidator(ErrorJSON(githubError, 202)).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return fmt.Errorf(gerr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetTopics returns the topics of a repository.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/repos#get-all-repository-topics
+func (s *RepoService) GetTopics(opts *v1alpha1.RepoSpec) ([]string, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/topics", opts.Org, opts.Name))
+
+	var res struct {
+		Names []string `json:"names"`
+	}
+
 	err := requests.URL(s.apiUrl).Path(pt).
 		Client(s.client).
 		Method(http.MethodGet).
 		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		Param("per_page", "100").
 		CheckStatus(200).
+		ToJSON(&res).
+		Fetch(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Names, nil
+}
+
+// ReplaceTopics replaces all the topics of a repository. An empty list
+// removes all the topics.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/repos#replace-all-repository-topics
+func (s *RepoService) ReplaceTopics(opts *v1alpha1.RepoSpec, topics []string) error {
+	if err := ValidateTopics(topics); err != nil {
+		return err
+	}
+
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/topics", opts.Org, opts.Name))
+
+	if topics == nil {
+		topics = []string{}
+	}
+
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPut).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(map[string]interface{}{
+			"names": topics,
+		}).
+		AddValidator(ErrorJSON(githubError, 200)).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return fmt.Errorf(gerr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// VulnerabilityAlertsEnabled checks whether Dependabot alerts are enabled.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/repos#check-if-vulnerability-alerts-are-enabled-for-a-repository
+func (s *RepoService) VulnerabilityAlertsEnabled(opts *v1alpha1.RepoSpec) (bool, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/vulnerability-alerts", opts.Org, opts.Name))
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(204).
 		Fetch(context.Background())
 	if err != nil {
 		if requests.HasStatusErr(err, 404) {
@@ -88,6 +524,134 @@ func (s *RepoService) Exists(opts *v1alpha1.RepoSpec) (bool, error) {
 	return true, nil
 }
 
+// SetVulnerabilityAlerts enables or disables Dependabot alerts.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/repos#enable-vulnerability-alerts
+func (s *RepoService) SetVulnerabilityAlerts(opts *v1alpha1.RepoSpec, enabled bool) error {
+	return s.toggle(fmt.Sprintf("repos/%s/%s/vulnerability-alerts", opts.Org, opts.Name), enabled)
+}
+
+// AutomatedSecurityFixesEnabled checks whether Dependabot security updates are enabled.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/repos#check-if-dependabot-security-updates-are-enabled-for-a-repository
+func (s *RepoService) AutomatedSecurityFixesEnabled(opts *v1alpha1.RepoSpec) (bool, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s/automated-security-fixes", opts.Org, opts.Name))
+
+	var res struct {
+		Enabled bool `json:"enabled"`
+	}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(&res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return res.Enabled, nil
+}
+
+// SetAutomatedSecurityFixes enables or disables Dependabot security updates.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/repos#enable-dependabot-security-updates
+func (s *RepoService) SetAutomatedSecurityFixes(opts *v1alpha1.RepoSpec, enabled bool) error {
+	return s.toggle(fmt.Sprintf("repos/%s/%s/automated-security-fixes", opts.Org, opts.Name), enabled)
+}
+
+// toggle enables a feature with a PUT on its endpoint, or disables it
+// with a DELETE.
+func (s *RepoService) toggle(endpoint string, enabled bool) error {
+	pt := path.Join(s.apiExtraPath, endpoint)
+
+	method := http.MethodDelete
+	if enabled {
+		method = http.MethodPut
+	}
+
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(method).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		AddValidator(ErrorJSON(githubError, 204)).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return fmt.Errorf(gerr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Update edits a repository so that it matches the desired state.
+// Only the optional settings that are declared in the spec are sent.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/repos#update-a-repository
+func (s *RepoService) Update(opts *v1alpha1.RepoSpec) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s", opts.Org, opts.Name))
+
+	body := settingsBody(opts)
+
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPatch).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, 200)).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return fmt.Errorf(gerr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// SetArchived archives or unarchives a repository.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/repos#update-a-repository
+func (s *RepoService) SetArchived(opts *v1alpha1.RepoSpec, archived bool) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("repos/%s/%s", opts.Org, opts.Name))
+
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPatch).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(map[string]interface{}{
+			"archived": archived,
+		}).
+		AddValidator(ErrorJSON(githubError, 200)).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return fmt.Errorf(gerr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
 // Deleting a repository requires admin access. If OAuth is used, the delete_repo scope is required.
 // https://docs.github.com/en/rest/repos/repos#get-a-repository
 func (s *RepoService) Delete(opts *v1alpha1.RepoSpec) error {
@@ -128,3 +692,103 @@ func (s *RepoService) isOrg(owner string) (bool, error) {
 
 	return true, nil
 }
+
+// createBody returns the payload used to create a repository.
+func createBody(opts *v1alpha1.RepoSpec) map[string]interface{} {
+	body := settingsBody(opts)
+	// the default branch and the security features can only be
+	// changed once the repository exists
+	delete(body, "default_branch")
+	delete(body, "security_and_analysis")
+	body["name"] = opts.Name
+	body["auto_init"] = ptr.Deref(opts.Initialize, true)
+	if opts.GitignoreTemplate != nil {
+		body["gitignore_template"] = *opts.GitignoreTemplate
+	}
+	if opts.LicenseTemplate != nil {
+		body["license_template"] = *opts.LicenseTemplate
+	}
+
+	return body
+}
+
+// settingsBody returns the repository settings that can be both set on
+// creation and edited afterwards. Optional settings that are not declared
+// in the spec are left out so that GitHub keeps its current values.
+func settingsBody(opts *v1alpha1.RepoSpec) map[string]interface{} {
+	body := map[string]interface{}{}
+
+	if opts.Visibility != nil {
+		body["visibility"] = *opts.Visibility
+	} else {
+		body["private"] = opts.Private
+	}
+
+	setIfNotNil(body, "description", opts.Description)
+	setIfNotNil(body, "homepage", opts.Homepage)
+	setIfNotNil(body, "default_branch", opts.DefaultBranch)
+	setIfNotNil(body, "has_issues", opts.HasIssues)
+	setIfNotNil(body, "has_projects", opts.HasProjects)
+	setIfNotNil(body, "has_wiki", opts.HasWiki)
+	setIfNotNil(body, "has_discussions", opts.HasDiscussions)
+	setIfNotNil(body, "allow_squash_merge", opts.AllowSquashMerge)
+	setIfNotNil(body, "allow_merge_commit", opts.AllowMergeCommit)
+	setIfNotNil(body, "allow_rebase_merge", opts.AllowRebaseMerge)
+	setIfNotNil(body, "allow_auto_merge", opts.AllowAutoMerge)
+	setIfNotNil(body, "delete_branch_on_merge", opts.DeleteBranchOnMerge)
+	setIfNotNil(body, "squash_merge_commit_title", opts.SquashMergeCommitTitle)
+	setIfNotNil(body, "squash_merge_commit_message", opts.SquashMergeCommitMessage)
+	setIfNotNil(body, "merge_commit_title", opts.MergeCommitTitle)
+	setIfNotNil(body, "merge_commit_message", opts.MergeCommitMessage)
+	setIfNotNil(body, "is_template", opts.IsTemplate)
+
+	if sa := opts.SecurityAndAnalysis; sa != nil {
+		features := map[string]interface{}{}
+		setFeature(features, "advanced_security", sa.AdvancedSecurity)
+		setFeature(features, "secret_scanning", sa.SecretScanning)
+		setFeature(features, "secret_scanning_push_protection", sa.SecretScanningPushProtection)
+		setFeature(features, "secret_scanning_validity_checks", sa.SecretScanningValidityChecks)
+		if len(features) > 0 {
+			body["security_and_analysis"] = features
+		}
+	}
+
+	return body
+}
+
+func setFeature(features map[string]interface{}, key string, enabled *bool) {
+	if enabled == nil {
+		return
+	}
+	status := "disabled"
+	if *enabled {
+		status = "enabled"
+	}
+	features[key] = map[string]string{"status": status}
+}
+
+func setIfNotNil[T any](body map[string]interface{}, key string, val *T) {
+	if val != nil {
+		body[key] = *val
+	}
+}
+
+const maxTopics = 20
+
+var topicRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// ValidateTopics checks the topics against the GitHub rules: at most 20
+// topics, each starting with a lowercase letter or a number, made only of
+// lowercase letters, numbers and hyphens, and at most 50 characters long.
+func ValidateTopics(topics []string) error {
+	if len(topics) > maxTopics {
+		return fmt.Errorf("too many topics: %d (max %d)", len(topics), maxTopics)
+	}
+	for _, t := range topics {
+		if !topicRegexp.MatchString(t) {
+			return fmt.Errorf("invalid topic '%s': topics must start with a lowercase letter or a number, "+
+				"contain only lowercase letters, numbers and hyphens, and be at most 50 characters", t)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+
+	"github.com/krateoplatformops/github-provider/apis/repo/v1alpha1"
+)
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     v1alpha1.RepoSpec
+		path     string
+		expected map[string]interface{}
+		err      bool
+	}{
+		{
+			name:     "organization repository",
+			spec:     v1alpha1.RepoSpec{Org: "acme", Name: "web", Private: true, Description: ptr.To("The website")},
+			path:     "/orgs/acme/repos",
+			expected: map[string]interface{}{"auto_init": true, "description": "The website", "name": "web", "private": true},
+		},
+		{
+			name:     "user repository",
+			spec:     v1alpha1.RepoSpec{Org: "octocat", Name: "web", DefaultBranch: ptr.To("trunk"), Initialize: ptr.To(false)},
+			path:     "/user/repos",
+			expected: map[string]interface{}{"auto_init": false, "name": "web", "private": false},
+		},
+		{
+			name:     "from a template",
+			spec:     v1alpha1.RepoSpec{Org: "acme", Name: "web", Visibility: ptr.To("internal"), TemplateRef: &v1alpha1.TemplateRef{Owner: "acme", Name: "template"}},
+			path:     "/repos/acme/template/generate",
+			expected: map[string]interface{}{"include_all_branches": false, "name": "web", "owner": "acme", "private": true},
+		},
+		{
+			name:     "organization fork",
+			spec:     v1alpha1.RepoSpec{Org: "acme", Name: "web", Fork: &v1alpha1.ForkSource{Owner: "upstream", Repo: "site"}},
+			path:     "/repos/upstream/site/forks",
+			expected: map[string]interface{}{"default_branch_only": false, "name": "web", "organization": "acme"},
+		},
+		{
+			name:     "user fork",
+			spec:     v1alpha1.RepoSpec{Org: "octocat", Name: "web", Fork: &v1alpha1.ForkSource{Owner: "upstream", Repo: "site", DefaultBranchOnly: ptr.To(true)}},
+			path:     "/repos/upstream/site/forks",
+			expected: map[string]interface{}{"default_branch_only": true, "name": "web"},
+		},
+		{
+			name: "template and fork",
+			spec: v1alpha1.RepoSpec{Org: "acme", Name: "web", TemplateRef: &v1alpha1.TemplateRef{Owner: "acme", Name: "template"}, Fork: &v1alpha1.ForkSource{Owner: "upstream", Repo: "site"}},
+			err:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			body := map[string]interface{}{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/orgs/acme":
+					fmt.Fprint(w, `{}`)
+				case r.Method == http.MethodPost && r.URL.Path == tc.path:
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+					}
+					if strings.HasSuffix(r.URL.Path, "/forks") {
+						w.WriteHeader(http.StatusAccepted)
+					} else {
+						w.WriteHeader(http.StatusCreated)
+					}
+					fmt.Fprint(w, `{"id": 1}`)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer srv.Close()
+
+			repos := NewClient(ClientOpts{ApiURL: srv.URL + "/", Token: "token", HttpClient: srv.Client()}).Repos()
+
+			repo, err := repos.Create(&tc.spec)
+			if (err != nil) != tc.err {
+				t.Fatalf("expected an error: %v, got %v", tc.err, err)
+			}
+			if tc.err {
+				return
+			}
+			if repo.ID != 1 {
+				t.Fatalf("expected the created repository, got %v", repo)
+			}
+			if fmt.Sprint(body) != fmt.Sprint(tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, body)
+			}
+		})
+	}
+}
+
+func TestSettingsBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     v1alpha1.RepoSpec
+		expected map[string]interface{}
+	}{
+		{
+			name:     "undeclared settings are left out",
+			spec:     v1alpha1.RepoSpec{Org: "acme", Name: "web", Private: true},
+			expected: map[string]interface{}{"private": true},
+		},
+		{
+			name:     "visibility replaces private",
+			spec:     v1alpha1.RepoSpec{Org: "acme", Name: "web", Private: true, Visibility: ptr.To("internal"), HasWiki: ptr.To(false)},
+			expected: map[string]interface{}{"visibility": "internal", "has_wiki": false},
+		},
+		{
+			name: "security features",
+			spec: v1alpha1.RepoSpec{Org: "acme", Name: "web", SecurityAndAnalysis: &v1alpha1.SecurityAndAnalysis{
+				SecretScanning:      ptr.To(true),
+				VulnerabilityAlerts: ptr.To(true),
+			}},
+			expected: map[string]interface{}{"private": false, "security_and_analysis": map[string]interface{}{
+				"secret_scanning": map[string]interface{}{"status": "enabled"},
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			body := settingsBody(&tc.spec)
+			if fmt.Sprint(body) != fmt.Sprint(tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, body)
+			}
+		})
+	}
+}
+
+func TestValidateTopics(t *testing.T) {
+	tests := []struct {
+		name   string
+		topics []string
+		err    bool
+	}{
+		{name: "none", topics: nil},
+		{name: "valid", topics: []string{"go", "kubernetes-operator", "web3"}},
+		{name: "uppercase", topics: []string{"Go"}, err: true},
+		{name: "leading hyphen", topics: []string{"-go"}, err: true},
+		{name: "space", topics: []string{"web site"}, err: true},
+		{name: "too long", topics: []string{strings.Repeat("a", 51)}, err: true},
+		{name: "longest", topics: []string{strings.Repeat("a", 50)}},
+		{name: "too many", topics: strings.Split(strings.Repeat("go,", 20)+"web", ","), err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateTopics(tc.topics); (err != nil) != tc.err {
+				t.Fatalf("expected an error: %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestHasBranches(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/web/branches":
+			fmt.Fprint(w, `[{"name": "main"}]`)
+		case "/repos/acme/empty/branches":
+			fmt.Fprint(w, `[]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	repos := NewClient(ClientOpts{ApiURL: srv.URL + "/", Token: "token", HttpClient: srv.Client()}).Repos()
+
+	tests := []struct {
+		name     string
+		repo     string
+		expected bool
+	}{
+		{name: "pushed", repo: "web", expected: true},
+		{name: "empty", repo: "empty", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := repos.HasBranches(&v1alpha1.RepoSpec{Org: "acme", Name: tc.repo})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,842 @@
+package github
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"slices"
+
+	"github.com/carlmjohnson/requests"
+	"github.com/krateoplatformops/github-provider/apis/ruleset/v1alpha1"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const rulesetsPerPage = 100
+
+// RepositoryRulesetService provides methods for managing the rulesets of a repository.
+type RepositoryRulesetService struct {
+	rulesets
+}
+
+// OrganizationRulesetService provides methods for managing the rulesets of an organization.
+type OrganizationRulesetService struct {
+	rulesets
+}
+
+// Ruleset represents a ruleset.
+type Ruleset struct {
+	ID           int64                `json:"id"`
+	Name         string               `json:"name"`
+	Target       string               `json:"target"`
+	SourceType   string               `json:"source_type"`
+	Enforcement  string               `json:"enforcement"`
+	BypassActors []RulesetBypassActor `json:"bypass_actors"`
+	Conditions   *RulesetConditions   `json:"conditions"`
+	Rules        []RulesetRule        `json:"rules"`
+}
+
+// RulesetBypassActor is an actor that can bypass a ruleset.
+type RulesetBypassActor struct {
+	ActorID    *int64 `json:"actor_id"`
+	ActorType  string `json:"actor_type"`
+	BypassMode string `json:"bypass_mode"`
+}
+
+// RulesetConditions are the conditions that select what a ruleset applies to.
+type RulesetConditions struct {
+	RefName            *RulesetRefName            `json:"ref_name"`
+	RepositoryName     *RulesetRepositoryName     `json:"repository_name"`
+	RepositoryProperty *RulesetRepositoryProperty `json:"repository_property"`
+}
+
+// RulesetRefName selects the refs a ruleset applies to.
+type RulesetRefName struct {
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+}
+
+// RulesetRepositoryName selects the repositories a ruleset applies to by name.
+type RulesetRepositoryName struct {
+	Include   []string `json:"include"`
+	Exclude   []string `json:"exclude"`
+	Protected bool     `json:"protected"`
+}
+
+// RulesetRepositoryProperty selects the repositories a ruleset applies to by property.
+type RulesetRepositoryProperty struct {
+	Include []RulesetPropertyTarget `json:"include"`
+	Exclude []RulesetPropertyTarget `json:"exclude"`
+}
+
+// RulesetPropertyTarget matches the repositories with a property value.
+type RulesetPropertyTarget struct {
+	Name           string   `json:"name"`
+	PropertyValues []string `json:"property_values"`
+	Source         string   `json:"source"`
+}
+
+// RulesetRule is a rule of a ruleset.
+type RulesetRule struct {
+	Type       string          `json:"type"`
+	Parameters *RuleParameters `json:"parameters"`
+}
+
+// RuleParameters holds the parameters of every type of rule, only the
+// ones matching the rule type are set.
+type RuleParameters struct {
+	UpdateAllowsFetchAndMerge      bool     `json:"update_allows_fetch_and_merge"`
+	RequiredDeploymentEnvironments []string `json:"required_deployment_environments"`
+
+	RequiredApprovingReviewCount   int      `json:"required_approving_review_count"`
+	DismissStaleReviewsOnPush      bool     `json:"dismiss_stale_reviews_on_push"`
+	RequireCodeOwnerReview         bool     `json:"require_code_owner_review"`
+	RequireLastPushApproval        bool     `json:"require_last_push_approval"`
+	RequiredReviewThreadResolution bool     `json:"required_review_thread_resolution"`
+	AllowedMergeMethods            []string `json:"allowed_merge_methods"`
+
+	RequiredStatusChecks []struct {
+		Context       string `json:"context"`
+		IntegrationID *int64 `json:"integration_id"`
+	} `json:"required_status_checks"`
+	StrictRequiredStatusChecksPolicy bool `json:"strict_required_status_checks_policy"`
+	DoNotEnforceOnCreate             bool `json:"do_not_enforce_on_create"`
+
+	Name     *string `json:"name"`
+	Negate   bool    `json:"negate"`
+	Operator string  `json:"operator"`
+	Pattern  string  `json:"pattern"`
+
+	RestrictedFilePaths      []string `json:"restricted_file_paths"`
+	MaxFilePathLength        int      `json:"max_file_path_length"`
+	RestrictedFileExtensions []string `json:"restricted_file_extensions"`
+	MaxFileSize              int      `json:"max_file_size"`
+}
+
+// ToSpec converts the rule into its spec form.
+func (r *RulesetRule) ToSpec() v1alpha1.Rule {
+	res := v1alpha1.Rule{Type: r.Type}
+
+	p := r.Parameters
+	if p == nil {
+		p = &RuleParameters{}
+	}
+
+	switch r.Type {
+	case "update":
+		if r.Parameters != nil {
+			res.Update = &v1alpha1.UpdateParameters{
+				UpdateAllowsFetchAndMerge: p.UpdateAllowsFetchAndMerge,
+			}
+		}
+	case "required_deployments":
+		res.RequiredDeployments = &v1alpha1.RequiredDeploymentsParameters{
+			RequiredDeploymentEnvironments: p.RequiredDeploymentEnvironments,
+		}
+	case "pull_request":
+		res.PullRequest = &v1alpha1.PullRequestParameters{
+			RequiredApprovingReviewCount:   p.RequiredApprovingReviewCount,
+			DismissStaleReviewsOnPush:      p.DismissStaleReviewsOnPush,
+			RequireCodeOwnerReview:         p.RequireCodeOwnerReview,
+			RequireLastPushApproval:        p.RequireLastPushApproval,
+			RequiredReviewThreadResolution: p.RequiredReviewThreadResolution,
+			AllowedMergeMethods:            p.AllowedMergeMethods,
+		}
+	case "required_status_checks":
+		checks := make([]v1alpha1.RequiredStatusCheck, 0, len(p.RequiredStatusChecks))
+		for _, c := range p.RequiredStatusChecks {
+			checks = append(checks, v1alpha1.RequiredStatusCheck{Context: c.Context, IntegrationId: c.IntegrationID})
+		}
+		res.RequiredStatusChecks = &v1alpha1.RequiredStatusChecksParameters{
+			RequiredStatusChecks:             checks,
+			StrictRequiredStatusChecksPolicy: p.StrictRequiredStatusChecksPolicy,
+			DoNotEnforceOnCreate:             p.DoNotEnforceOnCreate,
+		}
+	case "commit_message_pattern", "commit_author_email_pattern", "committer_email_pattern",
+		"branch_name_pattern", "tag_name_pattern":
+		res.Pattern = &v1alpha1.PatternParameters{
+			Name:     p.Name,
+			Negate:   p.Negate,
+			Operator: p.Operator,
+			Pattern:  p.Pattern,
+		}
+	case "file_path_restriction":
+		res.FilePathRestriction = &v1alpha1.FilePathRestrictionParameters{
+			RestrictedFilePaths: p.RestrictedFilePaths,
+		}
+	case "max_file_path_length":
+		res.MaxFilePathLength = &v1alpha1.MaxFilePathLengthParameters{
+			MaxFilePathLength: p.MaxFilePathLength,
+		}
+	case "file_extension_restriction":
+		res.FileExtensionRestriction = &v1alpha1.FileExtensionRestrictionParameters{
+			RestrictedFileExtensions: p.RestrictedFileExtensions,
+		}
+	case "max_file_size":
+		res.MaxFileSize = &v1alpha1.MaxFileSizeParameters{
+			MaxFileSize: p.MaxFileSize,
+		}
+	}
+
+	return res
+}
+
+// newRepositoryRulesetService returns a new RepositoryRulesetService.
+func newRepositoryRulesetService(httpClient *http.Client, apiUrl, extraPath, token string) *RepositoryRulesetService {
+	return &RepositoryRulesetService{
+		rulesets: rulesets{
+			client:       httpClient,
+			apiUrl:       apiUrl,
+			apiExtraPath: extraPath,
+			token:        token,
+		},
+	}
+}
+
+// Find looks up a ruleset of the repository by name. It returns nil if no
+// such ruleset exists. Rulesets inherited from the organization are ignored.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/rules#get-all-repository-rulesets
+func (s *RepositoryRulesetService) Find(opts *v1alpha1.RepositoryRulesetSpec) (*Ruleset, error) {
+	return s.find(fmt.Sprintf("repos/%s/%s", opts.Owner, opts.Repo), opts.Name)
+}
+
+// Get fetches a ruleset of the repository. It returns nil if the ruleset does not exist.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/rules#get-a-repository-ruleset
+func (s *RepositoryRulesetService) Get(opts *v1alpha1.RepositoryRulesetSpec, id int64) (*Ruleset, error) {
+	return s.get(fmt.Sprintf("repos/%s/%s", opts.Owner, opts.Repo), id)
+}
+
+// Create creates a ruleset for the repository.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/rules#create-a-repository-ruleset
+func (s *RepositoryRulesetService) Create(opts *v1alpha1.RepositoryRulesetSpec) (*Ruleset, error) {
+	body, err := repositoryRulesetBody(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.create(fmt.Sprintf("repos/%s/%s", opts.Owner, opts.Repo), body)
+}
+
+// Update replaces a ruleset of the repository with the desired one.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/rules#update-a-repository-ruleset
+func (s *RepositoryRulesetService) Update(opts *v1alpha1.RepositoryRulesetSpec, id int64) error {
+	body, err := repositoryRulesetBody(opts)
+	if err != nil {
+		return err
+	}
+
+	return s.update(fmt.Sprintf("repos/%s/%s", opts.Owner, opts.Repo), id, body)
+}
+
+// Delete deletes a ruleset of the repository.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/rules#delete-a-repository-ruleset
+func (s *RepositoryRulesetService) Delete(opts *v1alpha1.RepositoryRulesetSpec, id int64) error {
+	return s.delete(fmt.Sprintf("repos/%s/%s", opts.Owner, opts.Repo), id)
+}
+
+func repositoryRulesetBody(opts *v1alpha1.RepositoryRulesetSpec) (map[string]interface{}, error) {
+	body, err := rulesetBody(&opts.RulesetSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := map[string]interface{}{}
+	if c := opts.Conditions; c != nil && c.RefName != nil {
+		conditions["ref_name"] = refNameBody(c.RefName)
+	}
+	body["conditions"] = conditions
+
+	return body, nil
+}
+
+// newOrganizationRulesetService returns a new OrganizationRulesetService.
+func newOrganizationRulesetService(httpClient *http.Client, apiUrl, extraPath, token string) *OrganizationRulesetService {
+	return &OrganizationRulesetService{
+		rulesets: rulesets{
+			client:       httpClient,
+			apiUrl:       apiUrl,
+			apiExtraPath: extraPath,
+			token:        token,
+		},
+	}
+}
+
+// Find looks up a ruleset of the organization by name. It returns nil if no
+// such ruleset exists.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/rules#get-all-organization-repository-rulesets
+func (s *OrganizationRulesetService) Find(opts *v1alpha1.OrganizationRulesetSpec) (*Ruleset, error) {
+	return s.find(fmt.Sprintf("orgs/%s", opts.Org), opts.Name)
+}
+
+// Get fetches a ruleset of the organization. It returns nil if the ruleset does not exist.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/rules#get-an-organization-repository-ruleset
+func (s *OrganizationRulesetService) Get(opts *v1alpha1.OrganizationRulesetSpec, id int64) (*Ruleset, error) {
+	return s.get(fmt.Sprintf("orgs/%s", opts.Org), id)
+}
+
+// Create creates a ruleset for the organization.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/rules#create-an-organization-repository-ruleset
+func (s *OrganizationRulesetService) Create(opts *v1alpha1.OrganizationRulesetSpec) (*Ruleset, error) {
+	body, err := organizationRulesetBody(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.create(fmt.Sprintf("orgs/%s", opts.Org), body)
+}
+
+// Update replaces a ruleset of the organization with the desired one.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/rules#update-an-organization-repository-ruleset
+func (s *OrganizationRulesetService) Update(opts *v1alpha1.OrganizationRulesetSpec, id int64) error {
+	body, err := organizationRulesetBody(opts)
+	if err != nil {
+		return err
+	}
+
+	return s.update(fmt.Sprintf("orgs/%s", opts.Org), id, body)
+}
+
+// Delete deletes a ruleset of the organization.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/rules#delete-an-organization-repository-ruleset
+func (s *OrganizationRulesetService) Delete(opts *v1alpha1.OrganizationRulesetSpec, id int64) error {
+	return s.delete(fmt.Sprintf("orgs/%s", opts.Org), id)
+}
+
+func organizationRulesetBody(opts *v1alpha1.OrganizationRulesetSpec) (map[string]interface{}, error) {
+	body, err := rulesetBody(&opts.RulesetSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := map[string]interface{}{}
+	if c := opts.Conditions; c != nil {
+		if c.RefName != nil {
+			conditions["ref_name"] = refNameBody(c.RefName)
+		}
+		if c.RepositoryName != nil {
+			conditions["repository_name"] = map[string]interface{}{
+				"include":   nonNil(c.RepositoryName.Include),
+				"exclude":   nonNil(c.RepositoryName.Exclude),
+				"protected": c.RepositoryName.Protected,
+			}
+		}
+		if c.RepositoryProperty != nil {
+			conditions["repository_property"] = map[string]interface{}{
+				"include": propertyTargetsBody(c.RepositoryProperty.Include),
+				"exclude": propertyTargetsBody(c.RepositoryProperty.Exclude),
+			}
+		}
+	}
+	body["conditions"] = conditions
+
+	return body, nil
+}
+
+// rulesets implements the ruleset endpoints shared by repositories and
+// organizations. The base is the path of the owner of the rulesets.
+type rulesets struct {
+	client       *http.Client
+	apiUrl       string
+	apiExtraPath string
+	token        string
+}
+
+func (s *rulesets) find(base, name string) (*Ruleset, error) {
+	pt := path.Join(s.apiExtraPath, base, "rulesets")
+
+	for page := 1; ; page++ {
+		res := []Ruleset{}
+
+		err := requests.URL(s.apiUrl).Path(pt).
+			Client(s.client).
+			Method(http.MethodGet).
+			Header("Authorization", fmt.Sprintf("token %s", s.token)).
+			Param("includes_parents", "false").
+			ParamInt("per_page", rulesetsPerPage).
+			ParamInt("page", page).
+			CheckStatus(200).
+			ToJSON(&res).
+			Fetch(context.Background())
+		if err != nil {
+			if requests.HasStatusErr(err, 404) {
+				return nil, nil
+			}
+
+			return nil, err
+		}
+
+		for _, rs := range res {
+			if rs.Name == name {
+				return s.get(base, rs.ID)
+			}
+		}
+
+		if len(res) < rulesetsPerPage {
+			return nil, nil
+		}
+	}
+}
+
+func (s *rulesets) get(base string, id int64) (*Ruleset, error) {
+	pt := path.Join(s.apiExtraPath, base, fmt.Sprintf("rulesets/%d", id))
+
+	res := &Ruleset{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *rulesets) create(base string, body map[string]interface{}) (*Ruleset, error) {
+	pt := path.Join(s.apiExtraPath, base, "rulesets")
+
+	res := &Ruleset{}
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPost).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, 201)).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return nil, fmt.Errorf(gerr.Error())
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *rulesets) update(base string, id int64, body map[string]interface{}) error {
+	pt := path.Join(s.apiExtraPath, base, fmt.Sprintf("rulesets/%d", id))
+
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPut).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, 200)).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return fmt.Errorf(gerr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *rulesets) delete(base string, id int64) error {
+	pt := path.Join(s.apiExtraPath, base, fmt.Sprintf("rulesets/%d", id))
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodDelete).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(204).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// rulesetBody returns the request body for the settings shared by
+// repository and organization rulesets.
+func rulesetBody(opts *v1alpha1.RulesetSpec) (map[string]interface{}, error) {
+	body := map[string]interface{}{
+		"name":        opts.Name,
+		"enforcement": opts.Enforcement,
+	}
+	setIfNotNil(body, "target", opts.Target)
+
+	actors := []map[string]interface{}{}
+	for _, a := range opts.BypassActors {
+		actor := map[string]interface{}{"actor_type": a.ActorType}
+		setIfNotNil(actor, "actor_id", a.ActorId)
+		setIfNotNil(actor, "bypass_mode", a.BypassMode)
+		actors = append(actors, actor)
+	}
+	body["bypass_actors"] = actors
+
+	rules := []map[string]interface{}{}
+	for _, r := range opts.Rules {
+		rule, err := ruleBody(&r)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	body["rules"] = rules
+
+	return body, nil
+}
+
+func ruleBody(r *v1alpha1.Rule) (map[string]interface{}, error) {
+	missing := func(field string) error {
+		return fmt.Errorf("rule %s requires %s parameters", r.Type, field)
+	}
+
+	var params map[string]interface{}
+
+	switch r.Type {
+	case "update":
+		if p := r.Update; p != nil {
+			params = map[string]interface{}{
+				"update_allows_fetch_and_merge": p.UpdateAllowsFetchAndMerge,
+			}
+		}
+	case "required_deployments":
+		p := r.RequiredDeployments
+		if p == nil {
+			return nil, missing("requiredDeployments")
+		}
+		params = map[string]interface{}{
+			"required_deployment_environments": nonNil(p.RequiredDeploymentEnvironments),
+		}
+	case "pull_request":
+		p := r.PullRequest
+		if p == nil {
+			return nil, missing("pullRequest")
+		}
+		params = map[string]interface{}{
+			"required_approving_review_count":   p.RequiredApprovingReviewCount,
+			"dismiss_stale_reviews_on_push":     p.DismissStaleReviewsOnPush,
+			"require_code_owner_review":         p.RequireCodeOwnerReview,
+			"require_last_push_approval":        p.RequireLastPushApproval,
+			"required_review_thread_resolution": p.RequiredReviewThreadResolution,
+		}
+		if p.AllowedMergeMethods != nil {
+			params["allowed_merge_methods"] = p.AllowedMergeMethods
+		}
+	case "required_status_checks":
+		p := r.RequiredStatusChecks
+		if p == nil {
+			return nil, missing("requiredStatusChecks")
+		}
+		checks := []map[string]interface{}{}
+		for _, c := range p.RequiredStatusChecks {
+			check := map[string]interface{}{"context": c.Context}
+			setIfNotNil(check, "integration_id", c.IntegrationId)
+			checks = append(checks, check)
+		}
+		params = map[string]interface{}{
+			"required_status_checks":               checks,
+			"strict_required_status_checks_policy": p.StrictRequiredStatusChecksPolicy,
+			"do_not_enforce_on_create":             p.DoNotEnforceOnCreate,
+		}
+	case "commit_message_pattern", "commit_author_email_pattern", "committer_email_pattern",
+		"branch_name_pattern", "tag_name_pattern":
+		p := r.Pattern
+		if p == nil {
+			return nil, missing("pattern")
+		}
+		params = map[string]interface{}{
+			"negate":   p.Negate,
+			"operator": p.Operator,
+			"pattern":  p.Pattern,
+		}
+		setIfNotNil(params, "name", p.Name)
+	case "file_path_restriction":
+		p := r.FilePathRestriction
+		if p == nil {
+			return nil, missing("filePathRestriction")
+		}
+		params = map[string]interface{}{
+			"restricted_file_paths": nonNil(p.RestrictedFilePaths),
+		}
+	case "max_file_path_length":
+		p := r.MaxFilePathLength
+		if p == nil {
+			return nil, missing("maxFilePathLength")
+		}
+		params = map[string]interface{}{
+			"max_file_path_length": p.MaxFilePathLength,
+		}
+	case "file_extension_restriction":
+		p := r.FileExtensionRestriction
+		if p == nil {
+			return nil, missing("fileExtensionRestriction")
+		}
+		params = map[string]interface{}{
+			"restricted_file_extensions": nonNil(p.RestrictedFileExtensions),
+		}
+	case "max_file_size":
+		p := r.MaxFileSize
+		if p == nil {
+			return nil, missing("maxFileSize")
+		}
+		params = map[string]interface{}{
+			"max_file_size": p.MaxFileSize,
+		}
+	}
+
+	res := map[string]interface{}{"type": r.Type}
+	if params != nil {
+		res["parameters"] = params
+	}
+	return res, nil
+}
+
+func refNameBody(c *v1alpha1.RefNameCondition) map[string]interface{} {
+	return map[string]interface{}{
+		"include": nonNil(c.Include),
+		"exclude": nonNil(c.Exclude),
+	}
+}
+
+func propertyTargetsBody(targets []v1alpha1.RepositoryPropertyTarget) []map[string]interface{} {
+	res := []map[string]interface{}{}
+	for _, t := range targets {
+		target := map[string]interface{}{
+			"name":            t.Name,
+			"property_values": nonNil(t.PropertyValues),
+		}
+		setIfNotNil(target, "source", t.Source)
+		res = append(res, target)
+	}
+	return res
+}
+
+func nonNil(l []string) []string {
+	if l == nil {
+		return []string{}
+	}
+	return l
+}
+
+// Diff compares the desired settings shared by repository and organization
+// rulesets with the observed ruleset and returns a description of each
+// setting that differs.
+func (r *Ruleset) Diff(spec *v1alpha1.RulesetSpec) []string {
+	diff := []string{}
+
+	diff = DiffValue(diff, "name", spec.Name, r.Name)
+	if spec.Target != nil {
+		diff = DiffValue(diff, "target", *spec.Target, r.Target)
+	}
+	diff = DiffValue(diff, "enforcement", spec.Enforcement, r.Enforcement)
+
+	wantActors := make([]string, 0, len(spec.BypassActors))
+	for _, a := range spec.BypassActors {
+		wantActors = append(wantActors, actorKey(a.ActorType, a.ActorId, ptr.Deref(a.BypassMode, "always")))
+	}
+	gotActors := make([]string, 0, len(r.BypassActors))
+	for _, a := range r.BypassActors {
+		gotActors = append(gotActors, actorKey(a.ActorType, a.ActorID, a.BypassMode))
+	}
+	diff = DiffItems(diff, "bypassActors", wantActors, gotActors)
+
+	observed := map[string]v1alpha1.Rule{}
+	for _, x := range r.Rules {
+		observed[x.Type] = canonicalRule(x.ToSpec())
+	}
+
+	for _, x := range spec.Rules {
+		want := canonicalRule(x)
+		got, ok := observed[x.Type]
+		if !ok {
+			diff = append(diff, fmt.Sprintf("rules.%s: present (observed: missing)", x.Type))
+			continue
+		}
+		delete(observed, x.Type)
+
+		// Optional parameters left unset take the value chosen by GitHub.
+		if want.PullRequest != nil && want.PullRequest.AllowedMergeMethods == nil && got.PullRequest != nil {
+			want.PullRequest.AllowedMergeMethods = got.PullRequest.AllowedMergeMethods
+		}
+		if want.Pattern != nil && want.Pattern.Name == nil && got.Pattern != nil {
+			want.Pattern.Name = got.Pattern.Name
+		}
+
+		if !reflect.DeepEqual(want, got) {
+			diff = append(diff, fmt.Sprintf("rules.%s: %s (observed: %s)", x.Type, ruleParams(want), ruleParams(got)))
+		}
+	}
+
+	for _, x := range r.Rules {
+		if _, ok := observed[x.Type]; ok {
+			diff = append(diff, fmt.Sprintf("rules.%s: missing (observed: present)", x.Type))
+		}
+	}
+
+	return diff
+}
+
+// DiffRefName compares the desired ref_name condition with the observed
+// one and returns a description of each setting that differs.
+func (r *Ruleset) DiffRefName(want *v1alpha1.RefNameCondition) []string {
+	diff := []string{}
+	if want == nil {
+		return diff
+	}
+
+	got := &RulesetRefName{}
+	if r.Conditions != nil && r.Conditions.RefName != nil {
+		got = r.Conditions.RefName
+	}
+
+	diff = DiffItems(diff, "conditions.refName.include", want.Include, got.Include)
+	diff = DiffItems(diff, "conditions.refName.exclude", want.Exclude, got.Exclude)
+	return diff
+}
+
+// DiffRepositoryName compares the desired repository_name condition with
+// the observed one and returns a description of each setting that differs.
+func (r *Ruleset) DiffRepositoryName(want *v1alpha1.RepositoryNameCondition) []string {
+	diff := []string{}
+	if want == nil {
+		return diff
+	}
+
+	got := &RulesetRepositoryName{}
+	if r.Conditions != nil && r.Conditions.RepositoryName != nil {
+		got = r.Conditions.RepositoryName
+	}
+
+	diff = DiffItems(diff, "conditions.repositoryName.include", want.Include, got.Include)
+	diff = DiffItems(diff, "conditions.repositoryName.exclude", want.Exclude, got.Exclude)
+	diff = DiffValue(diff, "conditions.repositoryName.protected", want.Protected, got.Protected)
+	return diff
+}
+
+// DiffRepositoryProperty compares the desired repository_property condition
+// with the observed one and returns a description of each setting that differs.
+func (r *Ruleset) DiffRepositoryProperty(want *v1alpha1.RepositoryPropertyCondition) []string {
+	diff := []string{}
+	if want == nil {
+		return diff
+	}
+
+	got := &RulesetRepositoryProperty{}
+	if r.Conditions != nil && r.Conditions.RepositoryProperty != nil {
+		got = r.Conditions.RepositoryProperty
+	}
+
+	wantKeys := func(targets []v1alpha1.RepositoryPropertyTarget) []string {
+		res := make([]string, 0, len(targets))
+		for _, t := range targets {
+			res = append(res, propertyKey(t.Name, ptr.Deref(t.Source, "custom"), t.PropertyValues))
+		}
+		return res
+	}
+	gotKeys := func(targets []RulesetPropertyTarget) []string {
+		res := make([]string, 0, len(targets))
+		for _, t := range targets {
+			res = append(res, propertyKey(t.Name, t.Source, t.PropertyValues))
+		}
+		return res
+	}
+
+	diff = DiffItems(diff, "conditions.repositoryProperty.include", wantKeys(want.Include), gotKeys(got.Include))
+	diff = DiffItems(diff, "conditions.repositoryProperty.exclude", wantKeys(want.Exclude), gotKeys(got.Exclude))
+	return diff
+}
+
+func propertyKey(name, source string, values []string) string {
+	values = slices.Clone(values)
+	slices.Sort(values)
+	return fmt.Sprintf("%s (%s) in %v", name, source, values)
+}
+
+func actorKey(actorType string, actorID *int64, bypassMode string) string {
+	// GitHub does not use an actor id for these actor types.
+	if actorID == nil || actorType == "OrganizationAdmin" || actorType == "DeployKey" {
+		return fmt.Sprintf("%s (%s)", actorType, bypassMode)
+	}
+	return fmt.Sprintf("%s %d (%s)", actorType, *actorID, bypassMode)
+}
+
+// canonicalRule returns a copy of the rule with the lists sorted and the
+// empty parameters removed, so that equivalent rules compare equal.
+func canonicalRule(r v1alpha1.Rule) v1alpha1.Rule {
+	res := *r.DeepCopy()
+
+	sorted := func(l []string) []string {
+		if len(l) == 0 {
+			return nil
+		}
+		slices.Sort(l)
+		return l
+	}
+
+	if res.Update != nil && !res.Update.UpdateAllowsFetchAndMerge {
+		res.Update = nil
+	}
+	if p := res.RequiredDeployments; p != nil {
+		p.RequiredDeploymentEnvironments = sorted(p.RequiredDeploymentEnvironments)
+	}
+	if p := res.PullRequest; p != nil {
+		p.AllowedMergeMethods = sorted(p.AllowedMergeMethods)
+	}
+	if p := res.RequiredStatusChecks; p != nil {
+		if len(p.RequiredStatusChecks) == 0 {
+			p.RequiredStatusChecks = nil
+		}
+		slices.SortFunc(p.RequiredStatusChecks, func(a, b v1alpha1.RequiredStatusCheck) int {
+			return cmp.Or(
+				cmp.Compare(a.Context, b.Context),
+				cmp.Compare(ptr.Deref(a.IntegrationId, 0), ptr.Deref(b.IntegrationId, 0)),
+			)
+		})
+	}
+	if p := res.FilePathRestriction; p != nil {
+		p.RestrictedFilePaths = sorted(p.RestrictedFilePaths)
+	}
+	if p := res.FileExtensionRestriction; p != nil {
+		p.RestrictedFileExtensions = sorted(p.RestrictedFileExtensions)
+	}
+
+	return res
+}
+
+// ruleParams describes the parameters of a rule.
+func ruleParams(r v1alpha1.Rule) string {
+	r.Type = ""
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err.Error()
+	}
+	return string(b)
+}
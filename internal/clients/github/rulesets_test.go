@@ -0,0 +1,227 @@
+package github
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/krateoplatformops/github-provider/apis/ruleset/v1alpha1"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+func TestCanonicalRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     v1alpha1.Rule
+		expected v1alpha1.Rule
+	}{
+		{
+			name:     "update without parameters",
+			rule:     v1alpha1.Rule{Type: "update", Update: &v1alpha1.UpdateParameters{}},
+			expected: v1alpha1.Rule{Type: "update"},
+		},
+		{
+			name:     "update with parameters",
+			rule:     v1alpha1.Rule{Type: "update", Update: &v1alpha1.UpdateParameters{UpdateAllowsFetchAndMerge: true}},
+			expected: v1alpha1.Rule{Type: "update", Update: &v1alpha1.UpdateParameters{UpdateAllowsFetchAndMerge: true}},
+		},
+		{
+			name: "sorted merge methods",
+			rule: v1alpha1.Rule{Type: "pull_request", PullRequest: &v1alpha1.PullRequestParameters{
+				AllowedMergeMethods: []string{"squash", "merge"},
+			}},
+			expected: v1alpha1.Rule{Type: "pull_request", PullRequest: &v1alpha1.PullRequestParameters{
+				AllowedMergeMethods: []string{"merge", "squash"},
+			}},
+		},
+		{
+			name: "sorted status checks",
+			rule: v1alpha1.Rule{Type: "required_status_checks", RequiredStatusChecks: &v1alpha1.RequiredStatusChecksParameters{
+				RequiredStatusChecks: []v1alpha1.RequiredStatusCheck{
+					{Context: "lint"},
+					{Context: "build", IntegrationId: ptr.To(int64(15368))},
+					{Context: "build"},
+				},
+			}},
+			expected: v1alpha1.Rule{Type: "required_status_checks", RequiredStatusChecks: &v1alpha1.RequiredStatusChecksParameters{
+				RequiredStatusChecks: []v1alpha1.RequiredStatusCheck{
+					{Context: "build"},
+					{Context: "build", IntegrationId: ptr.To(int64(15368))},
+					{Context: "lint"},
+				},
+			}},
+		},
+		{
+			name: "empty file paths",
+			rule: v1alpha1.Rule{Type: "file_path_restriction", FilePathRestriction: &v1alpha1.FilePathRestrictionParameters{
+				RestrictedFilePaths: []string{},
+			}},
+			expected: v1alpha1.Rule{Type: "file_path_restriction", FilePathRestriction: &v1alpha1.FilePathRestrictionParameters{}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			orig := *tc.rule.DeepCopy()
+			got := canonicalRule(tc.rule)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("expected %s, got %s", ruleParams(tc.expected), ruleParams(got))
+			}
+			if !reflect.DeepEqual(tc.rule, orig) {
+				t.Fatalf("the rule was modified")
+			}
+		})
+	}
+}
+
+func TestRulesetDiff(t *testing.T) {
+	spec := func(rules ...v1alpha1.Rule) *v1alpha1.RulesetSpec {
+		return &v1alpha1.RulesetSpec{
+			Name:        "main",
+			Enforcement: "active",
+			BypassActors: []v1alpha1.BypassActor{
+				{ActorType: "OrganizationAdmin", ActorId: ptr.To(int64(1))},
+			},
+			Rules: rules,
+		}
+	}
+	observed := func(rules ...RulesetRule) *Ruleset {
+		return &Ruleset{
+			Name:        "main",
+			Target:      "branch",
+			Enforcement: "active",
+			BypassActors: []RulesetBypassActor{
+				{ActorType: "OrganizationAdmin", BypassMode: "always"},
+			},
+			Rules: rules,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		spec     *v1alpha1.RulesetSpec
+		ruleset  *Ruleset
+		expected []string
+	}{
+		{
+			name:     "up to date",
+			spec:     spec(v1alpha1.Rule{Type: "deletion"}),
+			ruleset:  observed(RulesetRule{Type: "deletion"}),
+			expected: []string{},
+		},
+		{
+			name: "unset merge methods take the observed ones",
+			spec: spec(v1alpha1.Rule{Type: "pull_request", PullRequest: &v1alpha1.PullRequestParameters{
+				RequiredApprovingReviewCount: 1,
+			}}),
+			ruleset: observed(RulesetRule{Type: "pull_request", Parameters: &RuleParameters{
+				RequiredApprovingReviewCount: 1,
+				AllowedMergeMethods:          []string{"squash", "merge", "rebase"},
+			}}),
+			expected: []string{},
+		},
+		{
+			name:     "different enforcement",
+			spec:     &v1alpha1.RulesetSpec{Name: "main", Enforcement: "disabled"},
+			ruleset:  &Ruleset{Name: "main", Enforcement: "active"},
+			expected: []string{"enforcement: disabled (observed: active)"},
+		},
+		{
+			name:     "different bypass mode",
+			spec:     &v1alpha1.RulesetSpec{Name: "main", Enforcement: "active", BypassActors: []v1alpha1.BypassActor{{ActorType: "Team", ActorId: ptr.To(int64(2)), BypassMode: ptr.To("pull_request")}}},
+			ruleset:  &Ruleset{Name: "main", Enforcement: "active", BypassActors: []RulesetBypassActor{{ActorType: "Team", ActorID: ptr.To(int64(2)), BypassMode: "always"}}},
+			expected: []string{"bypassActors: [Team 2 (pull_request)] (observed: [Team 2 (always)])"},
+		},
+		{
+			name:     "missing and extra rules",
+			spec:     spec(v1alpha1.Rule{Type: "deletion"}),
+			ruleset:  observed(RulesetRule{Type: "non_fast_forward"}),
+			expected: []string{"rules.deletion: present (observed: missing)", "rules.non_fast_forward: missing (observed: present)"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := tc.ruleset.Diff(tc.spec)
+			if !slices.Equal(diff, tc.expected) {
+				t.Fatalf("expected %q, got %q", tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestDiffRepositoryName(t *testing.T) {
+	tests := []struct {
+		name     string
+		want     *v1alpha1.RepositoryNameCondition
+		ruleset  *Ruleset
+		expected []string
+	}{
+		{
+			name:     "not declared",
+			want:     nil,
+			ruleset:  &Ruleset{Conditions: &RulesetConditions{RepositoryName: &RulesetRepositoryName{Include: []string{"~ALL"}}}},
+			expected: []string{},
+		},
+		{
+			name:     "same patterns in another order",
+			want:     &v1alpha1.RepositoryNameCondition{Include: []string{"api-*", "web-*"}},
+			ruleset:  &Ruleset{Conditions: &RulesetConditions{RepositoryName: &RulesetRepositoryName{Include: []string{"web-*", "api-*"}, Exclude: []string{}}}},
+			expected: []string{},
+		},
+		{
+			name:     "missing condition",
+			want:     &v1alpha1.RepositoryNameCondition{Include: []string{"~ALL"}, Protected: true},
+			ruleset:  &Ruleset{},
+			expected: []string{"conditions.repositoryName.include: [~ALL] (observed: [])", "conditions.repositoryName.protected: true (observed: false)"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := tc.ruleset.DiffRepositoryName(tc.want)
+			if !slices.Equal(diff, tc.expected) {
+				t.Fatalf("expected %q, got %q", tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestDiffRepositoryProperty(t *testing.T) {
+	observed := func(targets ...RulesetPropertyTarget) *Ruleset {
+		return &Ruleset{Conditions: &RulesetConditions{RepositoryProperty: &RulesetRepositoryProperty{Include: targets}}}
+	}
+
+	tests := []struct {
+		name     string
+		want     *v1alpha1.RepositoryPropertyCondition
+		ruleset  *Ruleset
+		expected []string
+	}{
+		{
+			name: "default source and other value order",
+			want: &v1alpha1.RepositoryPropertyCondition{Include: []v1alpha1.RepositoryPropertyTarget{
+				{Name: "team", PropertyValues: []string{"web", "api"}},
+			}},
+			ruleset:  observed(RulesetPropertyTarget{Name: "team", PropertyValues: []string{"api", "web"}, Source: "custom"}),
+			expected: []string{},
+		},
+		{
+			name: "different source",
+			want: &v1alpha1.RepositoryPropertyCondition{Include: []v1alpha1.RepositoryPropertyTarget{
+				{Name: "visibility", PropertyValues: []string{"public"}, Source: ptr.To("system")},
+			}},
+			ruleset:  observed(RulesetPropertyTarget{Name: "visibility", PropertyValues: []string{"public"}, Source: "custom"}),
+			expected: []string{"conditions.repositoryProperty.include: [visibility (system) in [public]] (observed: [visibility (custom) in [public]])"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := tc.ruleset.DiffRepositoryProperty(tc.want)
+			if !slices.Equal(diff, tc.expected) {
+				t.Fatalf("expected %q, got %q", tc.expected, diff)
+			}
+		})
+	}
+}
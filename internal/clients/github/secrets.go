@@ -0,0 +1,276 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/carlmjohnson/requests"
+	"github.com/krateoplatformops/github-provider/apis/common"
+	"github.com/krateoplatformops/github-provider/apis/secret/v1alpha1"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+	"golang.org/x/crypto/nacl/box"
+)
+
+const secretRepositoriesPerPage = 100
+
+// SecretService provides methods for managing the secrets of a secret
+// store (actions, dependabot or codespaces).
+type SecretService struct {
+	client       *http.Client
+	apiUrl       string
+	apiExtraPath string
+	token        string
+	app          string
+}
+
+// Scope is the owner of a secret or a variable: an organization, a
+// repository or a repository environment.
+type Scope struct {
+	Org         string
+	Owner       string
+	Repo        string
+	Environment string
+}
+
+// IsOrg reports whether the scope is an organization.
+func (s Scope) IsOrg() bool {
+	return len(s.Org) > 0
+}
+
+// Validate checks that the scope is either an organization or a
+// repository, optionally with an environment.
+func (s Scope) Validate() error {
+	switch {
+	case s.IsOrg():
+		if len(s.Owner) > 0 || len(s.Repo) > 0 || len(s.Environment) > 0 {
+			return fmt.Errorf("org cannot be set together with owner, repo or environment")
+		}
+	case len(s.Owner) == 0 || len(s.Repo) == 0:
+		return fmt.Errorf("either org or owner and repo must be set")
+	}
+	return nil
+}
+
+func (s Scope) String() string {
+	switch {
+	case s.IsOrg():
+		return fmt.Sprintf("org '%s'", s.Org)
+	case len(s.Environment) > 0:
+		return fmt.Sprintf("environment '%s' of repo '%s/%s'", s.Environment, s.Owner, s.Repo)
+	default:
+		return fmt.Sprintf("repo '%s/%s'", s.Owner, s.Repo)
+	}
+}
+
+// Secret represents a secret. Its value is never returned.
+type Secret struct {
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Visibility string    `json:"visibility"`
+}
+
+// SecretPublicKey is the key secret values must be encrypted with.
+type SecretPublicKey struct {
+	KeyID string `json:"key_id"`
+	Key   string `json:"key"`
+}
+
+// newSecretService returns a new SecretService for the given secret store.
+func newSecretService(httpClient *http.Client, apiUrl, extraPath, token, app string) *SecretService {
+	return &SecretService{
+		client:       httpClient,
+		apiUrl:       apiUrl,
+		apiExtraPath: extraPath,
+		token:        token,
+		app:          app,
+	}
+}
+
+// PublicKey fetches the key secret values must be encrypted with.
+//
+// GitHub API docs: https://docs.github.com/en/rest/actions/secrets#get-a-repository-public-key
+func (s *SecretService) PublicKey(scope Scope) (*SecretPublicKey, error) {
+	pt := path.Join(s.apiExtraPath, s.base(scope), "public-key")
+
+	res := &SecretPublicKey{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Get fetches a secret. It returns nil if the secret does not exist.
+//
+// GitHub API docs: https://docs.github.com/en/rest/actions/secrets#get-a-repository-secret
+func (s *SecretService) Get(scope Scope, name string) (*Secret, error) {
+	pt := path.Join(s.apiExtraPath, s.base(scope), name)
+
+	res := &Secret{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Put creates or updates a secret, encrypting the value with the public
+// key of the scope. The visibility and the selected repositories are only
+// used by organization secrets.
+//
+// GitHub API docs: https://docs.github.com/en/rest/actions/secrets#create-or-update-a-repository-secret
+func (s *SecretService) Put(scope Scope, opts *v1alpha1.SecretSpec, value string, repositoryIDs []int64) error {
+	key, err := s.PublicKey(scope)
+	if err != nil {
+		return err
+	}
+
+	recipient, err := base64.StdEncoding.DecodeString(key.Key)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	if len(recipient) != 32 {
+		return fmt.Errorf("invalid public key: %d bytes long", len(recipient))
+	}
+
+	sealed, err := box.SealAnonymous(nil, []byte(value), (*[32]byte)(recipient), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"encrypted_value": base64.StdEncoding.EncodeToString(sealed),
+		"key_id":          key.KeyID,
+	}
+	if scope.IsOrg() {
+		visibility := ptr.Deref(opts.Visibility, common.VisibilityPrivate)
+		body["visibility"] = visibility
+		if visibility == common.VisibilitySelected {
+			if repositoryIDs == nil {
+				repositoryIDs = []int64{}
+			}
+			body["selected_repository_ids"] = repositoryIDs
+		}
+	}
+
+	pt := path.Join(s.apiExtraPath, s.base(scope), opts.Name)
+
+	githubError := &GithubError{}
+
+	err = requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPut).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, 201, 204)).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return fmt.Errorf(gerr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// SelectedRepositories lists the names of the repositories that can
+// access an organization secret with the selected visibility.
+//
+// GitHub API docs: https://docs.github.com/en/rest/actions/secrets#list-selected-repositories-for-an-organization-secret
+func (s *SecretService) SelectedRepositories(scope Scope, name string) ([]string, error) {
+	pt := path.Join(s.apiExtraPath, s.base(scope), name, "repositories")
+
+	names := []string{}
+	for page := 1; ; page++ {
+		res := struct {
+			Repositories []struct {
+				Name string `json:"name"`
+			} `json:"repositories"`
+		}{}
+
+		err := requests.URL(s.apiUrl).Path(pt).
+			Client(s.client).
+			Method(http.MethodGet).
+			Header("Authorization", fmt.Sprintf("token %s", s.token)).
+			ParamInt("per_page", secretRepositoriesPerPage).
+			ParamInt("page", page).
+			CheckStatus(200).
+			ToJSON(&res).
+			Fetch(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range res.Repositories {
+			names = append(names, r.Name)
+		}
+
+		if len(res.Repositories) < secretRepositoriesPerPage {
+			return names, nil
+		}
+	}
+}
+
+// Delete deletes a secret.
+//
+// GitHub API docs: https://docs.github.com/en/rest/actions/secrets#delete-a-repository-secret
+func (s *SecretService) Delete(scope Scope, name string) error {
+	pt := path.Join(s.apiExtraPath, s.base(scope), name)
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodDelete).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(204).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// base returns the path of the secrets of the scope.
+func (s *SecretService) base(scope Scope) string {
+	switch {
+	case scope.IsOrg():
+		return fmt.Sprintf("orgs/%s/%s/secrets", scope.Org, s.app)
+	case len(scope.Environment) > 0:
+		return fmt.Sprintf("repos/%s/%s/environments/%s/secrets", scope.Owner, scope.Repo, scope.Environment)
+	default:
+		return fmt.Sprintf("repos/%s/%s/%s/secrets", scope.Owner, scope.Repo, s.app)
+	}
+}
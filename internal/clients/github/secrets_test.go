@@ -0,0 +1,148 @@
+package github
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+	"golang.org/x/crypto/nacl/box"
+
+	"github.com/krateoplatformops/github-provider/apis/common"
+	"github.com/krateoplatformops/github-provider/apis/secret/v1alpha1"
+)
+
+func TestScopeValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope Scope
+		err   bool
+	}{
+		{name: "organization", scope: Scope{Org: "acme"}},
+		{name: "repository", scope: Scope{Owner: "acme", Repo: "web"}},
+		{name: "environment", scope: Scope{Owner: "acme", Repo: "web", Environment: "production"}},
+		{name: "nothing", scope: Scope{}, err: true},
+		{name: "owner without repository", scope: Scope{Owner: "acme"}, err: true},
+		{name: "organization and repository", scope: Scope{Org: "acme", Owner: "acme", Repo: "web"}, err: true},
+		{name: "organization and environment", scope: Scope{Org: "acme", Environment: "production"}, err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.scope.Validate(); (err != nil) != tc.err {
+				t.Fatalf("expected an error: %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestSecretPut(t *testing.T) {
+	pub, priv, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		scope         Scope
+		spec          v1alpha1.SecretSpec
+		repositoryIDs []int64
+		path          string
+		expected      map[string]interface{}
+	}{
+		{
+			name:     "repository secret",
+			scope:    Scope{Owner: "acme", Repo: "web"},
+			spec:     v1alpha1.SecretSpec{Name: "TOKEN", Visibility: ptr.To(common.VisibilityAll)},
+			path:     "/repos/acme/web/actions/secrets/TOKEN",
+			expected: map[string]interface{}{},
+		},
+		{
+			name:     "private organization secret",
+			scope:    Scope{Org: "acme"},
+			spec:     v1alpha1.SecretSpec{Name: "TOKEN"},
+			path:     "/orgs/acme/actions/secrets/TOKEN",
+			expected: map[string]interface{}{"visibility": "private"},
+		},
+		{
+			name:          "organization secret of selected repositories",
+			scope:         Scope{Org: "acme"},
+			spec:          v1alpha1.SecretSpec{Name: "TOKEN", Visibility: ptr.To(common.VisibilitySelected)},
+			repositoryIDs: []int64{1, 2},
+			path:          "/orgs/acme/actions/secrets/TOKEN",
+			expected:      map[string]interface{}{"visibility": "selected", "selected_repository_ids": []interface{}{1.0, 2.0}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			body := map[string]interface{}{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == path.Join(path.Dir(tc.path), "public-key"):
+					fmt.Fprintf(w, `{"key_id": "42", "key": %q}`, base64.StdEncoding.EncodeToString(pub[:]))
+				case r.Method == http.MethodPut && r.URL.Path == tc.path:
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+					}
+					w.WriteHeader(http.StatusCreated)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer srv.Close()
+
+			secrets := NewClient(ClientOpts{ApiURL: srv.URL + "/", Token: "token", HttpClient: srv.Client()}).ActionsSecrets()
+			if err := secrets.Put(tc.scope, &tc.spec, "s3cr3t", tc.repositoryIDs); err != nil {
+				t.Fatal(err)
+			}
+
+			sealed, err := base64.StdEncoding.DecodeString(body["encrypted_value"].(string))
+			if err != nil {
+				t.Fatal(err)
+			}
+			value, ok := box.OpenAnonymous(nil, sealed, pub, priv)
+			if !ok || string(value) != "s3cr3t" {
+				t.Fatalf("expected the value to be sealed with the public key, got %q", value)
+			}
+			if body["key_id"] != "42" {
+				t.Fatalf("expected key id 42, got %v", body["key_id"])
+			}
+
+			delete(body, "encrypted_value")
+			delete(body, "key_id")
+			if fmt.Sprint(body) != fmt.Sprint(tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, body)
+			}
+		})
+	}
+}
+
+func TestSecretStores(t *testing.T) {
+	cli := NewClient(ClientOpts{ApiURL: "https://api.github.com/", Token: "token"})
+
+	tests := []struct {
+		name     string
+		secrets  *SecretService
+		scope    Scope
+		expected string
+	}{
+		{name: "dependabot organization", secrets: cli.DependabotSecrets(), scope: Scope{Org: "acme"}, expected: "orgs/acme/dependabot/secrets"},
+		{name: "dependabot repository", secrets: cli.DependabotSecrets(), scope: Scope{Owner: "acme", Repo: "web"}, expected: "repos/acme/web/dependabot/secrets"},
+		{name: "codespaces organization", secrets: cli.CodespacesSecrets(), scope: Scope{Org: "acme"}, expected: "orgs/acme/codespaces/secrets"},
+		{name: "codespaces repository", secrets: cli.CodespacesSecrets(), scope: Scope{Owner: "acme", Repo: "web"}, expected: "repos/acme/web/codespaces/secrets"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.secrets.base(tc.scope); got != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
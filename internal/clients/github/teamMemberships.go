@@ -0,0 +1,140 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/carlmjohnson/requests"
+	"github.com/krateoplatformops/github-provider/apis/team/v1alpha1"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const teamMembersPerPage = 100
+
+// TeamMembership represents the membership of a user in a team.
+type TeamMembership struct {
+	Role  string `json:"role"`
+	State string `json:"state"`
+}
+
+// GetMembership fetches the membership of a user in a team. It returns
+// nil if the user is not a member of the team.
+//
+// GitHub API docs: https://docs.github.com/en/rest/teams/members#get-team-membership-for-a-user
+func (s *TeamService) GetMembership(opts *v1alpha1.TeamMembershipSpec) (*TeamMembership, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("orgs/%s/teams/%s/memberships/%s", opts.Org, opts.TeamSlug, opts.Username))
+
+	res := &TeamMembership{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetMembership adds a user to a team or updates the role of the user.
+// Users who are not members of the organization are invited to join it,
+// and their membership is pending until they accept.
+//
+// GitHub API docs: https://docs.github.com/en/rest/teams/members#add-or-update-team-membership-for-a-user
+func (s *TeamService) SetMembership(opts *v1alpha1.TeamMembershipSpec) (*TeamMembership, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("orgs/%s/teams/%s/memberships/%s", opts.Org, opts.TeamSlug, opts.Username))
+
+	body := map[string]interface{}{
+		"role": ptr.Deref(opts.Role, v1alpha1.TeamRoleMember),
+	}
+
+	res := &TeamMembership{}
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPut).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, 200)).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return nil, fmt.Errorf(gerr.Error())
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RemoveMembership removes a user from a team.
+//
+// GitHub API docs: https://docs.github.com/en/rest/teams/members#remove-team-membership-for-a-user
+func (s *TeamService) RemoveMembership(opts *v1alpha1.TeamMembershipSpec) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("orgs/%s/teams/%s/memberships/%s", opts.Org, opts.TeamSlug, opts.Username))
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodDelete).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(204).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// ListMembers lists the logins of the members of a team with the given
+// role (member, maintainer or all). Pending members are not listed.
+//
+// GitHub API docs: https://docs.github.com/en/rest/teams/members#list-team-members
+func (s *TeamService) ListMembers(org, slug, role string) ([]string, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("orgs/%s/teams/%s/members", org, slug))
+
+	logins := []string{}
+	for page := 1; ; page++ {
+		res := []User{}
+
+		err := requests.URL(s.apiUrl).Path(pt).
+			Client(s.client).
+			Method(http.MethodGet).
+			Header("Authorization", fmt.Sprintf("token %s", s.token)).
+			Param("role", role).
+			ParamInt("per_page", teamMembersPerPage).
+			ParamInt("page", page).
+			CheckStatus(200).
+			ToJSON(&res).
+			Fetch(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range res {
+			logins = append(logins, u.Login)
+		}
+
+		if len(res) < teamMembersPerPage {
+			return logins, nil
+		}
+	}
+}
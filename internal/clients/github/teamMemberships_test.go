@@ -0,0 +1,71 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+
+	"github.com/krateoplatformops/github-provider/apis/team/v1alpha1"
+)
+
+func TestTeamMembership(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     *string
+		expected TeamMembership
+	}{
+		{name: "default role", expected: TeamMembership{Role: "member", State: "active"}},
+		{name: "maintainer", role: ptr.To("maintainer"), expected: TeamMembership{Role: "maintainer", State: "active"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			role := ""
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/orgs/acme/teams/web/memberships/octocat" {
+					http.NotFound(w, r)
+					return
+				}
+				if r.Method == http.MethodPut {
+					body := map[string]string{}
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+					}
+					role = body["role"]
+				}
+				if len(role) == 0 {
+					http.NotFound(w, r)
+					return
+				}
+				fmt.Fprintf(w, `{"role": %q, "state": "active"}`, role)
+			}))
+			defer srv.Close()
+
+			teams := NewClient(ClientOpts{ApiURL: srv.URL + "/", Token: "token", HttpClient: srv.Client()}).Teams()
+			spec := &v1alpha1.TeamMembershipSpec{Org: "acme", TeamSlug: "web", Username: "octocat", Role: tc.role}
+
+			got, err := teams.GetMembership(spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != nil {
+				t.Fatalf("expected no membership before it is set, got %v", got)
+			}
+
+			if _, err := teams.SetMembership(spec); err != nil {
+				t.Fatal(err)
+			}
+			got, err = teams.GetMembership(spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got == nil || *got != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
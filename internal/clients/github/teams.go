@@ -0,0 +1,242 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/carlmjohnson/requests"
+	"github.com/krateoplatformops/github-provider/apis/team/v1alpha1"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+// TeamService provides methods for managing the teams of an organization.
+type TeamService struct {
+	client       *http.Client
+	apiUrl       string
+	apiExtraPath string
+	token        string
+}
+
+// Team represents a team of an organization.
+type Team struct {
+	ID                  int64  `json:"id"`
+	Name                string `json:"name"`
+	Slug                string `json:"slug"`
+	Description         string `json:"description"`
+	Privacy             string `json:"privacy"`
+	NotificationSetting string `json:"notification_setting"`
+	HTMLURL             string `json:"html_url"`
+	Parent              *Team  `json:"parent"`
+}
+
+// Diff returns the differences between the team and the desired spec.
+// The privacy and the notification setting are only compared when set.
+func (t *Team) Diff(spec *v1alpha1.TeamSpec) []string {
+	diff := DiffValue(nil, "name", spec.Name, t.Name)
+	diff = DiffValue(diff, "description", ptr.Deref(spec.Description, ""), t.Description)
+	if spec.Privacy != nil {
+		diff = DiffValue(diff, "privacy", *spec.Privacy, t.Privacy)
+	}
+	if spec.NotificationSetting != nil {
+		diff = DiffValue(diff, "notificationSetting", *spec.NotificationSetting, t.NotificationSetting)
+	}
+	parent := ""
+	if t.Parent != nil {
+		parent = t.Parent.Slug
+	}
+	return DiffValue(diff, "parentTeam", ptr.Deref(spec.ParentTeam, ""), parent)
+}
+
+// newTeamService returns a new TeamService.
+func newTeamService(httpClient *http.Client, apiUrl, extraPath, token string) *TeamService {
+	return &TeamService{
+		client:       httpClient,
+		apiUrl:       apiUrl,
+		apiExtraPath: extraPath,
+		token:        token,
+	}
+}
+
+// GetBySlug fetches a team of the organization by slug. It returns nil if
+// the team does not exist.
+//
+// GitHub API docs: https://docs.github.com/en/rest/teams/teams#get-a-team-by-name
+func (s *TeamService) GetBySlug(org, slug string) (*Team, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("orgs/%s/teams/%s", org, slug))
+
+	res := &Team{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetByID fetches a team of the organization by its numeric identifier,
+// which does not change when the team is renamed. It returns nil if the
+// team does not exist.
+func (s *TeamService) GetByID(org string, id int64) (*Team, error) {
+	orgID, err := s.orgID(org)
+	if err != nil {
+		return nil, err
+	}
+	if orgID == 0 {
+		return nil, nil
+	}
+
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("organizations/%d/team/%d", orgID, id))
+
+	res := &Team{}
+
+	err = requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// orgID resolves the login of an organization to its numeric identifier.
+// It returns 0 if the organization does not exist.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/orgs#get-an-organization
+func (s *TeamService) orgID(org string) (int64, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("orgs/%s", org))
+
+	res := struct {
+		ID int64 `json:"id"`
+	}{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(&res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return res.ID, nil
+}
+
+// Create creates a team in the organization. The parent team, if any, is
+// given by id.
+//
+// GitHub API docs: https://docs.github.com/en/rest/teams/teams#create-a-team
+func (s *TeamService) Create(opts *v1alpha1.TeamSpec, parentID int64) (*Team, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("orgs/%s/teams", opts.Org))
+
+	body := teamBody(opts, parentID)
+	if parentID == 0 {
+		delete(body, "parent_team_id")
+	}
+
+	return s.write(http.MethodPost, pt, body, 201)
+}
+
+// Update updates the team with the given slug. The team is moved to the
+// top level when parentID is 0.
+//
+// GitHub API docs: https://docs.github.com/en/rest/teams/teams#update-a-team
+func (s *TeamService) Update(opts *v1alpha1.TeamSpec, slug string, parentID int64) (*Team, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("orgs/%s/teams/%s", opts.Org, slug))
+
+	return s.write(http.MethodPatch, pt, teamBody(opts, parentID), 200)
+}
+
+// Delete deletes the team with the given slug, along with its child teams.
+//
+// GitHub API docs: https://docs.github.com/en/rest/teams/teams#delete-a-team
+func (s *TeamService) Delete(org, slug string) error {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("orgs/%s/teams/%s", org, slug))
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodDelete).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(204).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *TeamService) write(method, pt string, body map[string]interface{}, status int) (*Team, error) {
+	res := &Team{}
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(method).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, status)).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return nil, fmt.Errorf(gerr.Error())
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// teamBody builds the request body of a team. A nil parent_team_id moves
+// the team to the top level.
+func teamBody(opts *v1alpha1.TeamSpec, parentID int64) map[string]interface{} {
+	body := map[string]interface{}{
+		"name":           opts.Name,
+		"description":    ptr.Deref(opts.Description, ""),
+		"parent_team_id": nil,
+	}
+	if parentID != 0 {
+		body["parent_team_id"] = parentID
+	}
+	if opts.Privacy != nil {
+		body["privacy"] = *opts.Privacy
+	}
+	if opts.NotificationSetting != nil {
+		body["notification_setting"] = *opts.NotificationSetting
+	}
+	return body
+}
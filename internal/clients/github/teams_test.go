@@ -0,0 +1,75 @@
+package github
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/krateoplatformops/github-provider/apis/team/v1alpha1"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+func TestTeamDiff(t *testing.T) {
+	team := &Team{
+		Name:                "Platform Team",
+		Slug:                "platform-team",
+		Description:         "Runs the platform",
+		Privacy:             "closed",
+		NotificationSetting: "notifications_enabled",
+		Parent:              &Team{Slug: "engineering"},
+	}
+
+	tests := []struct {
+		name     string
+		spec     v1alpha1.TeamSpec
+		team     *Team
+		expected []string
+	}{
+		{
+			name: "up to date",
+			spec: v1alpha1.TeamSpec{
+				Name:        "Platform Team",
+				Description: ptr.To("Runs the platform"),
+				Privacy:     ptr.To("closed"),
+				ParentTeam:  ptr.To("engineering"),
+			},
+			team:     team,
+			expected: nil,
+		},
+		{
+			name:     "renamed and moved to the top level",
+			spec:     v1alpha1.TeamSpec{Name: "Platform", Description: ptr.To("Runs the platform")},
+			team:     team,
+			expected: []string{"name: Platform (observed: Platform Team)", "parentTeam:  (observed: engineering)"},
+		},
+		{
+			name:     "description removed",
+			spec:     v1alpha1.TeamSpec{Name: "Platform Team", ParentTeam: ptr.To("engineering")},
+			team:     team,
+			expected: []string{"description:  (observed: Runs the platform)"},
+		},
+		{
+			name: "different privacy and notifications",
+			spec: v1alpha1.TeamSpec{
+				Name:                "Platform Team",
+				Description:         ptr.To("Runs the platform"),
+				Privacy:             ptr.To("secret"),
+				NotificationSetting: ptr.To("notifications_disabled"),
+				ParentTeam:          ptr.To("engineering"),
+			},
+			team: team,
+			expected: []string{
+				"privacy: secret (observed: closed)",
+				"notificationSetting: notifications_disabled (observed: notifications_enabled)",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := tc.team.Diff(&tc.spec)
+			if !slices.Equal(diff, tc.expected) {
+				t.Fatalf("expected %q, got %q", tc.expected, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/carlmjohnson/requests"
+)
+
+// UserService provides methods for looking up GitHub users.
+type UserService struct {
+	client       *http.Client
+	apiUrl       string
+	apiExtraPath string
+	token        string
+}
+
+// User represents a GitHub user or organization account.
+type User struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Type  string `json:"type"`
+}
+
+// newUserService returns a new UserService.
+func newUserService(httpClient *http.Client, apiUrl, extraPath, token string) *UserService {
+	return &UserService{
+		client:       httpClient,
+		apiUrl:       apiUrl,
+		apiExtraPath: extraPath,
+		token:        token,
+	}
+}
+
+// Get fetches a user by login. It returns nil if the user does not exist.
+//
+// GitHub API docs: https://docs.github.com/en/rest/users/users#get-a-user
+func (s *UserService) Get(login string) (*User, error) {
+	pt := path.Join(s.apiExtraPath, fmt.Sprintf("users/%s", login))
+
+	res := &User{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,203 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/carlmjohnson/requests"
+	"github.com/krateoplatformops/github-provider/apis/common"
+	"github.com/krateoplatformops/github-provider/apis/variable/v1alpha1"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const variableRepositoriesPerPage = 100
+
+// VariableService provides methods for managing GitHub Actions variables.
+type VariableService struct {
+	client       *http.Client
+	apiUrl       string
+	apiExtraPath string
+	token        string
+}
+
+// Variable represents a GitHub Actions variable.
+type Variable struct {
+	Name       string    `json:"name"`
+	Value      string    `json:"value"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Visibility string    `json:"visibility"`
+}
+
+// newVariableService returns a new VariableService.
+func newVariableService(httpClient *http.Client, apiUrl, extraPath, token string) *VariableService {
+	return &VariableService{
+		client:       httpClient,
+		apiUrl:       apiUrl,
+		apiExtraPath: extraPath,
+		token:        token,
+	}
+}
+
+// Get fetches a variable. It returns nil if the variable does not exist.
+//
+// GitHub API docs: https://docs.github.com/en/rest/actions/variables#get-a-repository-variable
+func (s *VariableService) Get(scope Scope, name string) (*Variable, error) {
+	pt := path.Join(s.apiExtraPath, variablesPath(scope), name)
+
+	res := &Variable{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Create creates a variable.
+//
+// GitHub API docs: https://docs.github.com/en/rest/actions/variables#create-a-repository-variable
+func (s *VariableService) Create(scope Scope, opts *v1alpha1.ActionsVariableSpec, value string, repositoryIDs []int64) error {
+	pt := path.Join(s.apiExtraPath, variablesPath(scope))
+
+	return s.write(http.MethodPost, pt, variableBody(scope, opts, value, repositoryIDs), 201)
+}
+
+// Update updates the value of a variable and, for organization variables,
+// the repositories that can access it.
+//
+// GitHub API docs: https://docs.github.com/en/rest/actions/variables#update-a-repository-variable
+func (s *VariableService) Update(scope Scope, opts *v1alpha1.ActionsVariableSpec, value string, repositoryIDs []int64) error {
+	pt := path.Join(s.apiExtraPath, variablesPath(scope), opts.Name)
+
+	return s.write(http.MethodPatch, pt, variableBody(scope, opts, value, repositoryIDs), 204)
+}
+
+// SelectedRepositories lists the names of the repositories that can
+// access an organization variable with the selected visibility.
+//
+// GitHub API docs: https://docs.github.com/en/rest/actions/variables#list-selected-repositories-for-an-organization-variable
+func (s *VariableService) SelectedRepositories(scope Scope, name string) ([]string, error) {
+	pt := path.Join(s.apiExtraPath, variablesPath(scope), name, "repositories")
+
+	names := []string{}
+	for page := 1; ; page++ {
+		res := struct {
+			Repositories []struct {
+				Name string `json:"name"`
+			} `json:"repositories"`
+		}{}
+
+		err := requests.URL(s.apiUrl).Path(pt).
+			Client(s.client).
+			Method(http.MethodGet).
+			Header("Authorization", fmt.Sprintf("token %s", s.token)).
+			ParamInt("per_page", variableRepositoriesPerPage).
+			ParamInt("page", page).
+			CheckStatus(200).
+			ToJSON(&res).
+			Fetch(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range res.Repositories {
+			names = append(names, r.Name)
+		}
+
+		if len(res.Repositories) < variableRepositoriesPerPage {
+			return names, nil
+		}
+	}
+}
+
+// Delete deletes a variable.
+//
+// GitHub API docs: https://docs.github.com/en/rest/actions/variables#delete-a-repository-variable
+func (s *VariableService) Delete(scope Scope, name string) error {
+	pt := path.Join(s.apiExtraPath, variablesPath(scope), name)
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodDelete).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(204).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *VariableService) write(method, pt string, body map[string]interface{}, status int) error {
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(method).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, status)).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return fmt.Errorf(gerr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// variableBody builds the request body of a variable. The visibility and
+// the selected repositories are only used by organization variables.
+func variableBody(scope Scope, opts *v1alpha1.ActionsVariableSpec, value string, repositoryIDs []int64) map[string]interface{} {
+	body := map[string]interface{}{
+		"name":  opts.Name,
+		"value": value,
+	}
+	if scope.IsOrg() {
+		visibility := ptr.Deref(opts.Visibility, common.VisibilityPrivate)
+		body["visibility"] = visibility
+		if visibility == common.VisibilitySelected {
+			if repositoryIDs == nil {
+				repositoryIDs = []int64{}
+			}
+			body["selected_repository_ids"] = repositoryIDs
+		}
+	}
+	return body
+}
+
+// variablesPath returns the path of the variables of the scope.
+func variablesPath(scope Scope) string {
+	switch {
+	case scope.IsOrg():
+		return fmt.Sprintf("orgs/%s/actions/variables", scope.Org)
+	case len(scope.Environment) > 0:
+		return fmt.Sprintf("repos/%s/%s/environments/%s/variables", scope.Owner, scope.Repo, scope.Environment)
+	default:
+		return fmt.Sprintf("repos/%s/%s/actions/variables", scope.Owner, scope.Repo)
+	}
+}
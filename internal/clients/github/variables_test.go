@@ -0,0 +1,76 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+
+	"github.com/krateoplatformops/github-provider/apis/common"
+	"github.com/krateoplatformops/github-provider/apis/variable/v1alpha1"
+)
+
+func TestVariableBody(t *testing.T) {
+	tests := []struct {
+		name          string
+		scope         Scope
+		spec          v1alpha1.ActionsVariableSpec
+		repositoryIDs []int64
+		expected      map[string]interface{}
+	}{
+		{
+			name:     "repository variable ignores the visibility",
+			scope:    Scope{Owner: "acme", Repo: "web"},
+			spec:     v1alpha1.ActionsVariableSpec{Name: "REGION", Visibility: ptr.To(common.VisibilityAll)},
+			expected: map[string]interface{}{"name": "REGION", "value": "eu"},
+		},
+		{
+			name:     "private organization variable",
+			scope:    Scope{Org: "acme"},
+			spec:     v1alpha1.ActionsVariableSpec{Name: "REGION"},
+			expected: map[string]interface{}{"name": "REGION", "value": "eu", "visibility": "private"},
+		},
+		{
+			name:     "organization variable without selected repositories",
+			scope:    Scope{Org: "acme"},
+			spec:     v1alpha1.ActionsVariableSpec{Name: "REGION", Visibility: ptr.To(common.VisibilitySelected)},
+			expected: map[string]interface{}{"name": "REGION", "value": "eu", "visibility": "selected", "selected_repository_ids": []int64{}},
+		},
+		{
+			name:          "organization variable of selected repositories",
+			scope:         Scope{Org: "acme"},
+			spec:          v1alpha1.ActionsVariableSpec{Name: "REGION", Visibility: ptr.To(common.VisibilitySelected)},
+			repositoryIDs: []int64{1, 2},
+			expected:      map[string]interface{}{"name": "REGION", "value": "eu", "visibility": "selected", "selected_repository_ids": []int64{1, 2}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			body := variableBody(tc.scope, &tc.spec, "eu", tc.repositoryIDs)
+			if fmt.Sprint(body) != fmt.Sprint(tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, body)
+			}
+		})
+	}
+}
+
+func TestVariablesPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		scope    Scope
+		expected string
+	}{
+		{name: "organization", scope: Scope{Org: "acme"}, expected: "orgs/acme/actions/variables"},
+		{name: "repository", scope: Scope{Owner: "acme", Repo: "web"}, expected: "repos/acme/web/actions/variables"},
+		{name: "environment", scope: Scope{Owner: "acme", Repo: "web", Environment: "production"}, expected: "repos/acme/web/environments/production/variables"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := variablesPath(tc.scope); got != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
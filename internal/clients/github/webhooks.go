@@ -0,0 +1,393 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/carlmjohnson/requests"
+	"github.com/krateoplatformops/github-provider/apis/webhook/v1alpha1"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const webhooksPerPage = 100
+
+// RepoWebhookService provides methods for managing the webhooks of a repository.
+type RepoWebhookService struct {
+	webhooks
+}
+
+// OrgWebhookService provides methods for managing the webhooks of an organization.
+type OrgWebhookService struct {
+	webhooks
+}
+
+// Webhook represents a webhook.
+type Webhook struct {
+	ID           int64                `json:"id"`
+	Name         string               `json:"name"`
+	Active       bool                 `json:"active"`
+	Events       []string             `json:"events"`
+	Config       WebhookConfig        `json:"config"`
+	LastResponse *WebhookLastResponse `json:"last_response"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+}
+
+// WebhookConfig is the delivery configuration of a webhook.
+type WebhookConfig struct {
+	URL         string      `json:"url"`
+	ContentType string      `json:"content_type"`
+	InsecureSSL WebhookFlag `json:"insecure_ssl"`
+}
+
+// WebhookFlag is a webhook setting that GitHub reports either as a string
+// or as a number.
+type WebhookFlag string
+
+// UnmarshalJSON accepts both "1" and 1.
+func (f *WebhookFlag) UnmarshalJSON(b []byte) error {
+	*f = WebhookFlag(strings.Trim(string(b), `"`))
+	return nil
+}
+
+// IsEnabled reports whether the setting is enabled.
+func (f WebhookFlag) IsEnabled() bool {
+	return f == "1"
+}
+
+// WebhookLastResponse is the response to the last delivery of a webhook.
+type WebhookLastResponse struct {
+	Code    *int   `json:"code"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// Delivered reports whether the webhook has been delivered at least once.
+func (r *WebhookLastResponse) Delivered() bool {
+	return r != nil && r.Code != nil
+}
+
+// Failed reports whether the last delivery of the webhook failed.
+func (r *WebhookLastResponse) Failed() bool {
+	return r.Delivered() && (*r.Code < 200 || *r.Code > 299)
+}
+
+// newRepoWebhookService returns a new RepoWebhookService.
+func newRepoWebhookService(httpClient *http.Client, apiUrl, extraPath, token string) *RepoWebhookService {
+	return &RepoWebhookService{
+		webhooks: webhooks{
+			client:       httpClient,
+			apiUrl:       apiUrl,
+			apiExtraPath: extraPath,
+			token:        token,
+		},
+	}
+}
+
+// Find looks up a webhook of the repository by URL. It returns nil if no
+// such webhook exists.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/webhooks#list-repository-webhooks
+func (s *RepoWebhookService) Find(opts *v1alpha1.RepoWebhookSpec) (*Webhook, error) {
+	return s.find(fmt.Sprintf("repos/%s/%s", opts.Owner, opts.Repo), opts.Url)
+}
+
+// Get fetches a webhook of the repository. It returns nil if the webhook does not exist.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/webhooks#get-a-repository-webhook
+func (s *RepoWebhookService) Get(opts *v1alpha1.RepoWebhookSpec, id int64) (*Webhook, error) {
+	return s.get(fmt.Sprintf("repos/%s/%s", opts.Owner, opts.Repo), id)
+}
+
+// Create creates a webhook for the repository, signing the payloads with
+// the given secret.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/webhooks#create-a-repository-webhook
+func (s *RepoWebhookService) Create(opts *v1alpha1.RepoWebhookSpec, secret string) (*Webhook, error) {
+	body := webhookBody(&opts.WebhookSpec, secret)
+	body["name"] = "web"
+
+	return s.create(fmt.Sprintf("repos/%s/%s", opts.Owner, opts.Repo), body)
+}
+
+// Update replaces the configuration and the events of a webhook of the
+// repository, setting the given secret.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/webhooks#update-a-repository-webhook
+func (s *RepoWebhookService) Update(opts *v1alpha1.RepoWebhookSpec, id int64, secret string) error {
+	return s.update(fmt.Sprintf("repos/%s/%s", opts.Owner, opts.Repo), id, webhookBody(&opts.WebhookSpec, secret))
+}
+
+// Delete deletes a webhook of the repository.
+//
+// GitHub API docs: https://docs.github.com/en/rest/repos/webhooks#delete-a-repository-webhook
+func (s *RepoWebhookService) Delete(opts *v1alpha1.RepoWebhookSpec, id int64) error {
+	return s.delete(fmt.Sprintf("repos/%s/%s", opts.Owner, opts.Repo), id)
+}
+
+// newOrgWebhookService returns a new OrgWebhookService.
+func newOrgWebhookService(httpClient *http.Client, apiUrl, extraPath, token string) *OrgWebhookService {
+	return &OrgWebhookService{
+		webhooks: webhooks{
+			client:       httpClient,
+			apiUrl:       apiUrl,
+			apiExtraPath: extraPath,
+			token:        token,
+		},
+	}
+}
+
+// Find looks up a webhook of the organization by URL. It returns nil if no
+// such webhook exists.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/webhooks#list-organization-webhooks
+func (s *OrgWebhookService) Find(opts *v1alpha1.OrgWebhookSpec) (*Webhook, error) {
+	return s.find(fmt.Sprintf("orgs/%s", opts.Org), opts.Url)
+}
+
+// Get fetches a webhook of the organization. It returns nil if the webhook does not exist.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/webhooks#get-an-organization-webhook
+func (s *OrgWebhookService) Get(opts *v1alpha1.OrgWebhookSpec, id int64) (*Webhook, error) {
+	return s.get(fmt.Sprintf("orgs/%s", opts.Org), id)
+}
+
+// Create creates a webhook for the organization, signing the payloads with
+// the given secret.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/webhooks#create-an-organization-webhook
+func (s *OrgWebhookService) Create(opts *v1alpha1.OrgWebhookSpec, secret string) (*Webhook, error) {
+	body := webhookBody(&opts.WebhookSpec, secret)
+	body["name"] = "web"
+
+	return s.create(fmt.Sprintf("orgs/%s", opts.Org), body)
+}
+
+// Update replaces the configuration and the events of a webhook of the
+// organization, setting the given secret.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/webhooks#update-an-organization-webhook
+func (s *OrgWebhookService) Update(opts *v1alpha1.OrgWebhookSpec, id int64, secret string) error {
+	return s.update(fmt.Sprintf("orgs/%s", opts.Org), id, webhookBody(&opts.WebhookSpec, secret))
+}
+
+// Ping triggers a ping event to be sent to a webhook of the organization.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/webhooks#ping-an-organization-webhook
+func (s *OrgWebhookService) Ping(opts *v1alpha1.OrgWebhookSpec, id int64) error {
+	return s.ping(fmt.Sprintf("orgs/%s", opts.Org), id)
+}
+
+// Delete deletes a webhook of the organization.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/webhooks#delete-an-organization-webhook
+func (s *OrgWebhookService) Delete(opts *v1alpha1.OrgWebhookSpec, id int64) error {
+	return s.delete(fmt.Sprintf("orgs/%s", opts.Org), id)
+}
+
+// Diff compares the desired webhook with the observed one and returns a
+// description of each setting that differs. The secret is not compared,
+// since GitHub never returns it.
+func (w *Webhook) Diff(spec *v1alpha1.WebhookSpec) []string {
+	diff := []string{}
+
+	diff = DiffValue(diff, "url", spec.Url, w.Config.URL)
+	diff = DiffValue(diff, "contentType", ptr.Deref(spec.ContentType, "form"), w.Config.ContentType)
+	diff = DiffValue(diff, "active", ptr.Deref(spec.Active, true), w.Active)
+	diff = DiffValue(diff, "insecureSsl", ptr.Deref(spec.InsecureSsl, false), w.Config.InsecureSSL.IsEnabled())
+	diff = DiffItems(diff, "events", webhookEvents(spec), w.Events)
+
+	return diff
+}
+
+// webhooks implements the webhook endpoints shared by repositories and
+// organizations. The base is the path of the owner of the webhooks.
+type webhooks struct {
+	client       *http.Client
+	apiUrl       string
+	apiExtraPath string
+	token        string
+}
+
+func (s *webhooks) find(base, url string) (*Webhook, error) {
+	pt := path.Join(s.apiExtraPath, base, "hooks")
+
+	for page := 1; ; page++ {
+		res := []Webhook{}
+
+		err := requests.URL(s.apiUrl).Path(pt).
+			Client(s.client).
+			Method(http.MethodGet).
+			Header("Authorization", fmt.Sprintf("token %s", s.token)).
+			ParamInt("per_page", webhooksPerPage).
+			ParamInt("page", page).
+			CheckStatus(200).
+			ToJSON(&res).
+			Fetch(context.Background())
+		if err != nil {
+			if requests.HasStatusErr(err, 404) {
+				return nil, nil
+			}
+
+			return nil, err
+		}
+
+		for i := range res {
+			if res[i].Config.URL == url {
+				return &res[i], nil
+			}
+		}
+
+		if len(res) < webhooksPerPage {
+			return nil, nil
+		}
+	}
+}
+
+func (s *webhooks) get(base string, id int64) (*Webhook, error) {
+	pt := path.Join(s.apiExtraPath, base, fmt.Sprintf("hooks/%d", id))
+
+	res := &Webhook{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodGet).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(200).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *webhooks) create(base string, body map[string]interface{}) (*Webhook, error) {
+	pt := path.Join(s.apiExtraPath, base, "hooks")
+
+	res := &Webhook{}
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPost).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, 201)).
+		ToJSON(res).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return nil, fmt.Errorf(gerr.Error())
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *webhooks) update(base string, id int64, body map[string]interface{}) error {
+	pt := path.Join(s.apiExtraPath, base, fmt.Sprintf("hooks/%d", id))
+
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPatch).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		BodyJSON(body).
+		AddValidator(ErrorJSON(githubError, 200)).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return fmt.Errorf(gerr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *webhooks) ping(base string, id int64) error {
+	pt := path.Join(s.apiExtraPath, base, fmt.Sprintf("hooks/%d/pings", id))
+
+	githubError := &GithubError{}
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodPost).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		AddValidator(ErrorJSON(githubError, 204)).
+		Fetch(context.Background())
+	if err != nil {
+		var gerr *GithubError
+		if errors.As(err, &gerr) {
+			return fmt.Errorf(gerr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *webhooks) delete(base string, id int64) error {
+	pt := path.Join(s.apiExtraPath, base, fmt.Sprintf("hooks/%d", id))
+
+	err := requests.URL(s.apiUrl).Path(pt).
+		Client(s.client).
+		Method(http.MethodDelete).
+		Header("Authorization", fmt.Sprintf("token %s", s.token)).
+		CheckStatus(204).
+		Fetch(context.Background())
+	if err != nil {
+		if requests.HasStatusErr(err, 404) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// webhookBody returns the request body for a webhook. The whole
+// configuration is always sent, since GitHub replaces it as a whole.
+func webhookBody(opts *v1alpha1.WebhookSpec, secret string) map[string]interface{} {
+	insecureSSL := "0"
+	if ptr.Deref(opts.InsecureSsl, false) {
+		insecureSSL = "1"
+	}
+
+	config := map[string]interface{}{
+		"url":          opts.Url,
+		"content_type": ptr.Deref(opts.ContentType, "form"),
+		"insecure_ssl": insecureSSL,
+	}
+	if len(secret) > 0 {
+		config["secret"] = secret
+	}
+
+	return map[string]interface{}{
+		"active": ptr.Deref(opts.Active, true),
+		"events": webhookEvents(opts),
+		"config": config,
+	}
+}
+
+func webhookEvents(opts *v1alpha1.WebhookSpec) []string {
+	if len(opts.Events) == 0 {
+		return []string{"push"}
+	}
+	return opts.Events
+}
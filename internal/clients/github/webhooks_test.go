@@ -0,0 +1,89 @@
+package github
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/krateoplatformops/github-provider/apis/webhook/v1alpha1"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+func TestWebhookDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     v1alpha1.WebhookSpec
+		observed string
+		expected []string
+	}{
+		{
+			name:     "defaults",
+			spec:     v1alpha1.WebhookSpec{Url: "https://example.com/hook"},
+			observed: `{"active": true, "events": ["push"], "config": {"url": "https://example.com/hook", "content_type": "form", "insecure_ssl": "0"}}`,
+			expected: []string{},
+		},
+		{
+			name: "up to date",
+			spec: v1alpha1.WebhookSpec{
+				Url:         "https://example.com/hook",
+				ContentType: ptr.To("json"),
+				Events:      []string{"push", "pull_request"},
+				Active:      ptr.To(false),
+				InsecureSsl: ptr.To(true),
+			},
+			observed: `{"active": false, "events": ["pull_request", "push"], "config": {"url": "https://example.com/hook", "content_type": "json", "insecure_ssl": 1}}`,
+			expected: []string{},
+		},
+		{
+			name:     "different settings",
+			spec:     v1alpha1.WebhookSpec{Url: "https://example.com/new", Events: []string{"release"}},
+			observed: `{"active": false, "events": ["push"], "config": {"url": "https://example.com/hook", "content_type": "json", "insecure_ssl": "1"}}`,
+			expected: []string{
+				"url: https://example.com/new (observed: https://example.com/hook)",
+				"contentType: form (observed: json)",
+				"active: true (observed: false)",
+				"insecureSsl: false (observed: true)",
+				"events: [release] (observed: [push])",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			hook := &Webhook{}
+			if err := json.Unmarshal([]byte(tc.observed), hook); err != nil {
+				t.Fatal(err)
+			}
+
+			diff := hook.Diff(&tc.spec)
+			if !slices.Equal(diff, tc.expected) {
+				t.Fatalf("expected %q, got %q", tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestWebhookLastResponse(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  *WebhookLastResponse
+		delivered bool
+		failed    bool
+	}{
+		{name: "never delivered", response: nil},
+		{name: "no code", response: &WebhookLastResponse{Status: "unused"}},
+		{name: "delivered", response: &WebhookLastResponse{Code: ptr.To(204), Status: "active"}, delivered: true},
+		{name: "failed", response: &WebhookLastResponse{Code: ptr.To(502), Status: "failed"}, delivered: true, failed: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.response.Delivered(); got != tc.delivered {
+				t.Errorf("Delivered: expected %v, got %v", tc.delivered, got)
+			}
+			if got := tc.response.Failed(); got != tc.failed {
+				t.Errorf("Failed: expected %v, got %v", tc.failed, got)
+			}
+		})
+	}
+}
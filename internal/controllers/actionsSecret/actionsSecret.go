@@ -0,0 +1,40 @@
+package actionsSecret
+
+import (
+	"github.com/krateoplatformops/provider-runtime/pkg/controller"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	secretv1alpha1 "github.com/krateoplatformops/github-provider/apis/secret/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+	"github.com/krateoplatformops/github-provider/internal/controllers/secret"
+)
+
+// Setup adds a controller that reconciles ActionsSecret managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	return secret.Setup(mgr, o, secret.Store[*secretv1alpha1.ActionsSecret]{
+		GroupKind:        secretv1alpha1.ActionsSecretGroupKind,
+		GroupVersionKind: secretv1alpha1.ActionsSecretGroupVersionKind,
+		Service:          (*github.Client).ActionsSecrets,
+		Connection:       connection,
+		Scope:            scopeOf,
+	})
+}
+
+func connection(cr *secretv1alpha1.ActionsSecret) secret.Connection {
+	return secret.Connection{
+		ApiUrl:      cr.Spec.ApiUrl,
+		Credentials: cr.Spec.Credentials,
+		Verbose:     cr.Spec.Verbose,
+	}
+}
+
+// scopeOf returns the owner of the secret: the organization, the repository
+// or the repository environment.
+func scopeOf(cr *secretv1alpha1.ActionsSecret) github.Scope {
+	return github.Scope{
+		Org:         cr.Spec.Org,
+		Owner:       cr.Spec.Owner,
+		Repo:        cr.Spec.Repo,
+		Environment: cr.Spec.Environment,
+	}
+}
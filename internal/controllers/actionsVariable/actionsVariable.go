@@ -0,0 +1,312 @@
+package actionsVariable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/controller"
+	"github.com/krateoplatformops/provider-runtime/pkg/ratelimiter"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/event"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/reconciler"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+
+	"github.com/krateoplatformops/github-provider/apis/common"
+	variablev1alpha1 "github.com/krateoplatformops/github-provider/apis/variable/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const (
+	errNotActionsVariable = "managed resource is not an actionsVariable custom resource"
+)
+
+// Setup adds a controller that reconciles Token managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := reconciler.ControllerName(variablev1alpha1.ActionsVariableGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+
+	recorder := mgr.GetEventRecorderFor(name)
+
+	r := reconciler.NewReconciler(mgr,
+		resource.ManagedKind(variablev1alpha1.ActionsVariableGroupVersionKind),
+		reconciler.WithExternalConnecter(&connector{
+			kube:     mgr.GetClient(),
+			log:      log,
+			recorder: recorder,
+		}),
+		reconciler.WithPollInterval(o.PollInterval),
+		reconciler.WithLogger(log),
+		reconciler.WithRecorder(event.NewAPIRecorder(recorder)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&variablev1alpha1.ActionsVariable{}).
+		Complete(ratelimiter.New(name, r, o.GlobalRateLimiter))
+}
+
+type connector struct {
+	kube     client.Client
+	log      logging.Logger
+	recorder record.EventRecorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (reconciler.ExternalClient, error) {
+	cr, ok := mg.(*variablev1alpha1.ActionsVariable)
+	if !ok {
+		return nil, errors.New(errNotActionsVariable)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	csr := spec.Credentials.SecretRef
+	if csr == nil {
+		return nil, fmt.Errorf("no credentials secret referenced")
+	}
+
+	token, err := resource.GetSecret(ctx, c.kube, csr.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := github.ClientOpts{
+		ApiURL: spec.ApiUrl,
+		Token:  token,
+	}
+	opts.HttpClient = clients.DefaultHttpClient()
+
+	if ptr.Deref(cr.Spec.Verbose, false) {
+		opts.HttpClient = &http.Client{
+			Transport: &clients.VerboseTracer{RoundTripper: http.DefaultTransport},
+			Timeout:   50 * time.Second,
+		}
+	}
+
+	return &external{
+		kube:  c.kube,
+		log:   c.log,
+		ghCli: github.NewClient(opts),
+		rec:   c.recorder,
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube  client.Client
+	log   logging.Logger
+	ghCli *github.Client
+	rec   record.EventRecorder
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	return nil // NOOP
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (reconciler.ExternalObservation, error) {
+	cr, ok := mg.(*variablev1alpha1.ActionsVariable)
+	if !ok {
+		return reconciler.ExternalObservation{}, errors.New(errNotActionsVariable)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	scope, err := scopeOf(spec)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	v, err := e.ghCli.ActionsVariables().Get(scope, spec.Name)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	if v == nil {
+		e.log.Debug("Variable not found", "scope", scope.String(), "name", spec.Name)
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	cr.Status.CreatedAt = &metav1.Time{Time: v.CreatedAt}
+	cr.Status.UpdatedAt = &metav1.Time{Time: v.UpdatedAt}
+
+	cr.SetConditions(prv1.Available())
+
+	value, err := e.value(ctx, spec)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	diff := []string{}
+	if v.Value != value {
+		diff = append(diff, fmt.Sprintf("value: %s (observed: %s)", value, v.Value))
+	}
+	if scope.IsOrg() {
+		visibility := ptr.Deref(spec.Visibility, common.VisibilityPrivate)
+		diff = github.DiffValue(diff, "visibility", visibility, v.Visibility)
+		if visibility == common.VisibilitySelected && v.Visibility == visibility {
+			repos, err := e.ghCli.ActionsVariables().SelectedRepositories(scope, spec.Name)
+			if err != nil {
+				return reconciler.ExternalObservation{}, err
+			}
+			diff = github.DiffItems(diff, "selectedRepositories", spec.SelectedRepositories, repos)
+		}
+	}
+	if len(diff) > 0 {
+		e.log.Debug("Variable is not up to date", "scope", scope.String(), "name", spec.Name, "diff", diff)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "NotUpToDate", "Variable '%s' of %s differs from desired state: %s", spec.Name, scope, strings.Join(diff, ", "))
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: false,
+			Diff:             strings.Join(diff, "\n"),
+		}, nil
+	}
+
+	e.log.Debug("Variable already exists", "scope", scope.String(), "name", spec.Name)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "AlreadyExists", "Variable '%s' of %s already exists", spec.Name, scope)
+
+	return reconciler.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*variablev1alpha1.ActionsVariable)
+	if !ok {
+		return errors.New(errNotActionsVariable)
+	}
+
+	cr.SetConditions(prv1.Creating())
+
+	spec := cr.Spec.DeepCopy()
+
+	scope, value, repositoryIDs, err := e.desired(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	err = e.ghCli.ActionsVariables().Create(scope, spec, value, repositoryIDs)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Variable created", "scope", scope.String(), "name", spec.Name)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "VariableCreated", "Variable '%s' of %s created", spec.Name, scope)
+
+	return nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*variablev1alpha1.ActionsVariable)
+	if !ok {
+		return errors.New(errNotActionsVariable)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	scope, value, repositoryIDs, err := e.desired(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	err = e.ghCli.ActionsVariables().Update(scope, spec, value, repositoryIDs)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Variable updated", "scope", scope.String(), "name", spec.Name)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "VariableUpdated", "Variable '%s' of %s updated", spec.Name, scope)
+
+	return nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*variablev1alpha1.ActionsVariable)
+	if !ok {
+		return errors.New(errNotActionsVariable)
+	}
+
+	cr.SetConditions(prv1.Deleting())
+
+	spec := cr.Spec.DeepCopy()
+
+	scope, err := scopeOf(spec)
+	if err != nil {
+		return err
+	}
+
+	err = e.ghCli.ActionsVariables().Delete(scope, spec.Name)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Variable deleted", "scope", scope.String(), "name", spec.Name)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "VariableDeleted", "Variable '%s' of %s deleted", spec.Name, scope)
+
+	return nil
+}
+
+// desired returns the scope, the value and the ids of the selected
+// repositories the variable must be written with.
+func (e *external) desired(ctx context.Context, spec *variablev1alpha1.ActionsVariableSpec) (github.Scope, string, []int64, error) {
+	scope, err := scopeOf(spec)
+	if err != nil {
+		return scope, "", nil, err
+	}
+
+	value, err := e.value(ctx, spec)
+	if err != nil {
+		return scope, "", nil, err
+	}
+
+	var repositoryIDs []int64
+	if scope.IsOrg() && ptr.Deref(spec.Visibility, "") == common.VisibilitySelected {
+		repositoryIDs, err = e.ghCli.Repos().IDs(scope.Org, spec.SelectedRepositories)
+		if err != nil {
+			return scope, "", nil, err
+		}
+	}
+
+	return scope, value, repositoryIDs, nil
+}
+
+// value returns the desired value of the variable, either inline or read
+// from the referenced ConfigMap.
+func (e *external) value(ctx context.Context, spec *variablev1alpha1.ActionsVariableSpec) (string, error) {
+	if spec.Value != nil {
+		return *spec.Value, nil
+	}
+	if spec.ValueFrom == nil {
+		return "", fmt.Errorf("either value or valueFrom must be set")
+	}
+	return resource.GetConfigMapValue(ctx, e.kube, spec.ValueFrom.DeepCopy())
+}
+
+// scopeOf returns the owner of the variable: the organization, the
+// repository or the repository environment.
+func scopeOf(spec *variablev1alpha1.ActionsVariableSpec) (github.Scope, error) {
+	scope := github.Scope{
+		Org:         spec.Org,
+		Owner:       spec.Owner,
+		Repo:        spec.Repo,
+		Environment: spec.Environment,
+	}
+	return scope, scope.Validate()
+}
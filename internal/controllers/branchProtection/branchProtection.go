@@ -0,0 +1,327 @@
+package branchProtection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/controller"
+	"github.com/krateoplatformops/provider-runtime/pkg/ratelimiter"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/event"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/reconciler"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+
+	branchProtectionv1alpha1 "github.com/krateoplatformops/github-provider/apis/branchProtection/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const (
+	errNotBranchProtection = "managed resource is not a branchProtection custom resource"
+)
+
+// Setup adds a controller that reconciles Token managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := reconciler.ControllerName(branchProtectionv1alpha1.BranchProtectionGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+
+	recorder := mgr.GetEventRecorderFor(name)
+
+	r := reconciler.NewReconciler(mgr,
+		resource.ManagedKind(branchProtectionv1alpha1.BranchProtectionGroupVersionKind),
+		reconciler.WithExternalConnecter(&connector{
+			kube:     mgr.GetClient(),
+			log:      log,
+			recorder: recorder,
+		}),
+		reconciler.WithPollInterval(o.PollInterval),
+		reconciler.WithLogger(log),
+		reconciler.WithRecorder(event.NewAPIRecorder(recorder)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&branchProtectionv1alpha1.BranchProtection{}).
+		Complete(ratelimiter.New(name, r, o.GlobalRateLimiter))
+}
+
+type connector struct {
+	kube     client.Client
+	log      logging.Logger
+	recorder record.EventRecorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (reconciler.ExternalClient, error) {
+	cr, ok := mg.(*branchProtectionv1alpha1.BranchProtection)
+	if !ok {
+		return nil, errors.New(errNotBranchProtection)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	csr := spec.Credentials.SecretRef
+	if csr == nil {
+		return nil, fmt.Errorf("no credentials secret referenced")
+	}
+
+	token, err := resource.GetSecret(ctx, c.kube, csr.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := github.ClientOpts{
+		ApiURL: spec.ApiUrl,
+		Token:  token,
+	}
+	opts.HttpClient = clients.DefaultHttpClient()
+
+	if ptr.Deref(cr.Spec.Verbose, false) {
+		opts.HttpClient = &http.Client{
+			Transport: &clients.VerboseTracer{RoundTripper: http.DefaultTransport},
+			Timeout:   50 * time.Second,
+		}
+	}
+
+	return &external{
+		kube:  c.kube,
+		log:   c.log,
+		ghCli: github.NewClient(opts),
+		rec:   c.recorder,
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube  client.Client
+	log   logging.Logger
+	ghCli *github.Client
+	rec   record.EventRecorder
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	return nil // NOOP
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (reconciler.ExternalObservation, error) {
+	cr, ok := mg.(*branchProtectionv1alpha1.BranchProtection)
+	if !ok {
+		return reconciler.ExternalObservation{}, errors.New(errNotBranchProtection)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	bp, err := e.ghCli.BranchProtection().Get(spec)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	if bp == nil {
+		e.log.Debug("Branch not protected", "owner", spec.Owner, "repo", spec.Repo, "branch", spec.Branch)
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	cr.SetConditions(prv1.Available())
+
+	diff := diffProtection(spec, bp)
+	if len(diff) > 0 {
+		e.log.Debug("Branch protection is not up to date", "owner", spec.Owner, "repo", spec.Repo, "branch", spec.Branch, "diff", diff)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "NotUpToDate", "Branch protection '%s/%s:%s' differs from desired state: %s", spec.Owner, spec.Repo, spec.Branch, strings.Join(diff, ", "))
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: false,
+			Diff:             strings.Join(diff, "\n"),
+		}, nil
+	}
+
+	e.log.Debug("Branch protection already exists", "owner", spec.Owner, "repo", spec.Repo, "branch", spec.Branch)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "AlreadyExists", "Branch protection '%s/%s:%s' already exists", spec.Owner, spec.Repo, spec.Branch)
+
+	return reconciler.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*branchProtectionv1alpha1.BranchProtection)
+	if !ok {
+		return errors.New(errNotBranchProtection)
+	}
+
+	cr.SetConditions(prv1.Creating())
+
+	spec := cr.Spec.DeepCopy()
+
+	err := e.ghCli.BranchProtection().Update(spec)
+	if err != nil {
+		return err
+	}
+
+	if spec.RequiredSignatures {
+		err := e.ghCli.BranchProtection().SetRequiredSignatures(spec, true)
+		if err != nil {
+			return err
+		}
+	}
+	e.log.Debug("Branch protection created", "owner", spec.Owner, "repo", spec.Repo, "branch", spec.Branch)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "BranchProtectionCreated", "Branch protection '%s/%s:%s' created", spec.Owner, spec.Repo, spec.Branch)
+
+	return nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*branchProtectionv1alpha1.BranchProtection)
+	if !ok {
+		return errors.New(errNotBranchProtection)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	bp, err := e.ghCli.BranchProtection().Get(spec)
+	if err != nil {
+		return err
+	}
+
+	err = e.ghCli.BranchProtection().Update(spec)
+	if err != nil {
+		return err
+	}
+
+	if bp == nil || bp.RequiredSignatures.IsEnabled() != spec.RequiredSignatures {
+		err := e.ghCli.BranchProtection().SetRequiredSignatures(spec, spec.RequiredSignatures)
+		if err != nil {
+			return err
+		}
+	}
+	e.log.Debug("Branch protection updated", "owner", spec.Owner, "repo", spec.Repo, "branch", spec.Branch)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "BranchProtectionUpdated", "Branch protection '%s/%s:%s' updated", spec.Owner, spec.Repo, spec.Branch)
+
+	return nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*branchProtectionv1alpha1.BranchProtection)
+	if !ok {
+		return errors.New(errNotBranchProtection)
+	}
+
+	cr.SetConditions(prv1.Deleting())
+
+	spec := cr.Spec.DeepCopy()
+
+	err := e.ghCli.BranchProtection().Delete(spec)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Branch protection deleted", "owner", spec.Owner, "repo", spec.Repo, "branch", spec.Branch)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "BranchProtectionDeleted", "Branch protection '%s/%s:%s' deleted", spec.Owner, spec.Repo, spec.Branch)
+
+	return nil
+}
+
+// diffProtection compares the desired protection with the observed one
+// and returns a description of each setting that differs.
+func diffProtection(spec *branchProtectionv1alpha1.BranchProtectionSpec, bp *github.BranchProtection) []string {
+	diff := []string{}
+
+	diff = github.DiffValue(diff, "enforceAdmins", spec.EnforceAdmins, bp.EnforceAdmins.IsEnabled())
+	diff = github.DiffValue(diff, "requiredLinearHistory", spec.RequiredLinearHistory, bp.RequiredLinearHistory.IsEnabled())
+	diff = github.DiffValue(diff, "allowForcePushes", spec.AllowForcePushes, bp.AllowForcePushes.IsEnabled())
+	diff = github.DiffValue(diff, "allowDeletions", spec.AllowDeletions, bp.AllowDeletions.IsEnabled())
+	diff = github.DiffValue(diff, "requiredConversationResolution", spec.RequiredConversationResolution, bp.RequiredConversationResolution.IsEnabled())
+	diff = github.DiffValue(diff, "requiredSignatures", spec.RequiredSignatures, bp.RequiredSignatures.IsEnabled())
+
+	switch want, got := spec.RequiredStatusChecks, bp.RequiredStatusChecks; {
+	case want == nil && got != nil:
+		diff = append(diff, "requiredStatusChecks: not required (observed: required)")
+	case want != nil && got == nil:
+		diff = append(diff, "requiredStatusChecks: required (observed: not required)")
+	case want != nil && got != nil:
+		diff = github.DiffValue(diff, "requiredStatusChecks.strict", want.Strict, got.Strict)
+
+		// GitHub reports the app it picked for the checks declared
+		// without one: only their context is compared.
+		anyApp := map[string]bool{}
+		wantChecks := make([]string, 0, len(want.Checks))
+		for _, c := range want.Checks {
+			if c.AppId == nil {
+				anyApp[c.Context] = true
+			}
+			wantChecks = append(wantChecks, checkKey(c.Context, c.AppId))
+		}
+		gotChecks := make([]string, 0, len(got.Checks))
+		for _, c := range got.Checks {
+			appID := c.AppID
+			if anyApp[c.Context] {
+				appID = nil
+			}
+			gotChecks = append(gotChecks, checkKey(c.Context, appID))
+		}
+		diff = github.DiffItems(diff, "requiredStatusChecks.checks", wantChecks, gotChecks)
+	}
+
+	switch want, got := spec.RequiredPullRequestReviews, bp.RequiredPullRequestReviews; {
+	case want == nil && got != nil:
+		diff = append(diff, "requiredPullRequestReviews: not required (observed: required)")
+	case want != nil && got == nil:
+		diff = append(diff, "requiredPullRequestReviews: required (observed: not required)")
+	case want != nil && got != nil:
+		diff = github.DiffValue(diff, "requiredPullRequestReviews.requiredApprovingReviewCount", want.RequiredApprovingReviewCount, got.RequiredApprovingReviewCount)
+		diff = github.DiffValue(diff, "requiredPullRequestReviews.requireCodeOwnerReviews", want.RequireCodeOwnerReviews, got.RequireCodeOwnerReviews)
+		diff = github.DiffValue(diff, "requiredPullRequestReviews.dismissStaleReviews", want.DismissStaleReviews, got.DismissStaleReviews)
+		diff = github.DiffValue(diff, "requiredPullRequestReviews.requireLastPushApproval", want.RequireLastPushApproval, got.RequireLastPushApproval)
+		if want.DismissalRestrictions != nil {
+			diff = diffActors(diff, "requiredPullRequestReviews.dismissalRestrictions", want.DismissalRestrictions, got.DismissalRestrictions.ToSpec())
+		}
+		if want.BypassPullRequestAllowances != nil {
+			diff = diffActors(diff, "requiredPullRequestReviews.bypassPullRequestAllowances", want.BypassPullRequestAllowances, got.BypassPullRequestAllowances.ToSpec())
+		}
+	}
+
+	switch want, got := spec.Restrictions, bp.Restrictions; {
+	case want == nil && got != nil:
+		diff = append(diff, "restrictions: not restricted (observed: restricted)")
+	case want != nil && got == nil:
+		diff = append(diff, "restrictions: restricted (observed: not restricted)")
+	case want != nil && got != nil:
+		diff = diffActors(diff, "restrictions", want, got.ToSpec())
+	}
+
+	return diff
+}
+
+func checkKey(context string, appID *int64) string {
+	if appID == nil {
+		return context
+	}
+	return fmt.Sprintf("%s (app %d)", context, *appID)
+}
+
+func diffActors(diff []string, field string, want, got *branchProtectionv1alpha1.Actors) []string {
+	if got == nil {
+		got = &branchProtectionv1alpha1.Actors{}
+	}
+	diff = github.DiffItems(diff, field+".users", want.Users, got.Users)
+	diff = github.DiffItems(diff, field+".teams", want.Teams, got.Teams)
+	diff = github.DiffItems(diff, field+".apps", want.Apps, got.Apps)
+	return diff
+}
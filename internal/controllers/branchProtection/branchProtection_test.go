@@ -0,0 +1,172 @@
+package branchProtection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	branchProtectionv1alpha1 "github.com/krateoplatformops/github-provider/apis/branchProtection/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestDiffProtection(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     branchProtectionv1alpha1.BranchProtectionSpec
+		observed string
+		expected []string
+	}{
+		{
+			name:     "nothing protected",
+			spec:     branchProtectionv1alpha1.BranchProtectionSpec{},
+			observed: `{}`,
+			expected: []string{},
+		},
+		{
+			name: "up to date",
+			spec: branchProtectionv1alpha1.BranchProtectionSpec{
+				EnforceAdmins: true,
+				RequiredStatusChecks: &branchProtectionv1alpha1.RequiredStatusChecks{
+					Strict: true,
+					Checks: []branchProtectionv1alpha1.StatusCheck{
+						{Context: "build", AppId: ptr.To(int64(15368))},
+						{Context: "lint"},
+					},
+				},
+				RequiredPullRequestReviews: &branchProtectionv1alpha1.RequiredPullRequestReviews{
+					RequiredApprovingReviewCount: 2,
+				},
+			},
+			observed: `{
+				"enforce_admins": {"enabled": true},
+				"required_status_checks": {"strict": true, "checks": [{"context": "lint"}, {"context": "build", "app_id": 15368}]},
+				"required_pull_request_reviews": {"required_approving_review_count": 2, "dismissal_restrictions": {"users": [{"login": "octocat"}]}}
+			}`,
+			expected: []string{},
+		},
+		{
+			name:     "toggle disabled",
+			spec:     branchProtectionv1alpha1.BranchProtectionSpec{AllowForcePushes: false},
+			observed: `{"allow_force_pushes": {"enabled": true}}`,
+			expected: []string{"allowForcePushes: false (observed: true)"},
+		},
+		{
+			name:     "status checks no longer required",
+			spec:     branchProtectionv1alpha1.BranchProtectionSpec{},
+			observed: `{"required_status_checks": {"strict": false, "checks": []}}`,
+			expected: []string{"requiredStatusChecks: not required (observed: required)"},
+		},
+		{
+			name: "app picked by GitHub",
+			spec: branchProtectionv1alpha1.BranchProtectionSpec{
+				RequiredStatusChecks: &branchProtectionv1alpha1.RequiredStatusChecks{
+					Checks: []branchProtectionv1alpha1.StatusCheck{{Context: "build"}},
+				},
+			},
+			observed: `{"required_status_checks": {"checks": [{"context": "build", "app_id": 15368}]}}`,
+			expected: []string{},
+		},
+		{
+			name: "status check of another app",
+			spec: branchProtectionv1alpha1.BranchProtectionSpec{
+				RequiredStatusChecks: &branchProtectionv1alpha1.RequiredStatusChecks{
+					Checks: []branchProtectionv1alpha1.StatusCheck{{Context: "build", AppId: ptr.To(int64(1))}},
+				},
+			},
+			observed: `{"required_status_checks": {"checks": [{"context": "build", "app_id": 2}]}}`,
+			expected: []string{"requiredStatusChecks.checks: [build (app 1)] (observed: [build (app 2)])"},
+		},
+		{
+			name: "dismissal restrictions",
+			spec: branchProtectionv1alpha1.BranchProtectionSpec{
+				RequiredPullRequestReviews: &branchProtectionv1alpha1.RequiredPullRequestReviews{
+					RequiredApprovingReviewCount: 1,
+					DismissalRestrictions:        &branchProtectionv1alpha1.Actors{Teams: []string{"admins"}},
+				},
+			},
+			observed: `{"required_pull_request_reviews": {"required_approving_review_count": 1}}`,
+			expected: []string{"requiredPullRequestReviews.dismissalRestrictions.teams: [admins] (observed: [])"},
+		},
+		{
+			name:     "restrictions required",
+			spec:     branchProtectionv1alpha1.BranchProtectionSpec{Restrictions: &branchProtectionv1alpha1.Actors{}},
+			observed: `{}`,
+			expected: []string{"restrictions: restricted (observed: not restricted)"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			bp := &github.BranchProtection{}
+			if err := json.Unmarshal([]byte(tc.observed), bp); err != nil {
+				t.Fatal(err)
+			}
+
+			diff := diffProtection(&tc.spec, bp)
+			if !slices.Equal(diff, tc.expected) {
+				t.Fatalf("expected %q, got %q", tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     branchProtectionv1alpha1.BranchProtectionSpec
+		observed string
+		expected []string
+	}{
+		{
+			name:     "signatures unchanged",
+			spec:     branchProtectionv1alpha1.BranchProtectionSpec{Owner: "acme", Repo: "web", Branch: "main", EnforceAdmins: true, RequiredSignatures: true},
+			observed: `{"required_signatures": {"enabled": true}}`,
+			expected: []string{"GET /repos/acme/web/branches/main/protection", "PUT /repos/acme/web/branches/main/protection"},
+		},
+		{
+			name:     "signatures no longer required",
+			spec:     branchProtectionv1alpha1.BranchProtectionSpec{Owner: "acme", Repo: "web", Branch: "main"},
+			observed: `{"required_signatures": {"enabled": true}}`,
+			expected: []string{"GET /repos/acme/web/branches/main/protection", "PUT /repos/acme/web/branches/main/protection", "DELETE /repos/acme/web/branches/main/protection/required_signatures"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := []string{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+				switch r.Method {
+				case http.MethodGet:
+					fmt.Fprint(w, tc.observed)
+				case http.MethodDelete:
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					fmt.Fprint(w, `{}`)
+				}
+			}))
+			defer srv.Close()
+
+			e := &external{
+				log:   logging.NewNopLogger(),
+				ghCli: github.NewClient(github.ClientOpts{ApiURL: srv.URL + "/", Token: "token", HttpClient: srv.Client()}),
+				rec:   record.NewFakeRecorder(10),
+			}
+			cr := &branchProtectionv1alpha1.BranchProtection{Spec: tc.spec}
+
+			if err := e.Update(context.Background(), cr); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(calls, tc.expected) {
+				t.Fatalf("expected %q, got %q", tc.expected, calls)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 
 	"github.com/krateoplatformops/provider-runtime/pkg/controller"
@@ -123,7 +124,12 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (reconciler
 
 	spec := cr.Spec.DeepCopy()
 
-	ok, err := e.ghCli.Collaborators().Exists(spec)
+	want, err := e.permission(cr)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	ok, err = e.ghCli.Collaborators().Exists(spec)
 	if err != nil {
 		return reconciler.ExternalObservation{}, err
 	}
@@ -134,8 +140,12 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (reconciler
 			return reconciler.ExternalObservation{}, err
 		}
 
+		cr.Status.Permission = ptr.To(permission.RoleName)
+		cr.Status.InvitationId = nil
+		cr.Status.InvitationExpiresAt = nil
+
 		cr.SetConditions(prv1.Available())
-		if permission == spec.Permission {
+		if permission.RoleName == github.InvitationPermission(want) {
 			e.log.Debug("Collaborator already exists", "org", spec.Org, "repo", spec.Repo, "username", spec.Username)
 			e.rec.Eventf(cr, corev1.EventTypeNormal, "AlreadyExists", "Collaborator '%s/%s/%s' already exists", spec.Org, spec.Repo, spec.Username)
 			return reconciler.ExternalObservation{
@@ -146,8 +156,40 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (reconciler
 			return reconciler.ExternalObservation{
 				ResourceExists:   true,
 				ResourceUpToDate: false,
+				Diff:             fmt.Sprintf("permission: %s (observed: %s)", want, permission.RoleName),
+			}, nil
+		}
+	}
+
+	inv, err := e.ghCli.Collaborators().FindInvitation(spec)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	if inv != nil && !inv.Expired {
+		cr.Status.Permission = ptr.To(inv.Permissions)
+		cr.Status.InvitationId = ptr.To(inv.ID)
+		cr.Status.InvitationExpiresAt = &metav1.Time{Time: inv.ExpiresAt()}
+
+		cr.SetConditions(collaboratorv1alpha1.InvitationPending())
+		if inv.Permissions == github.InvitationPermission(want) {
+			e.log.Debug("Collaborator invitation pending", "org", spec.Org, "repo", spec.Repo, "username", spec.Username, "id", inv.ID)
+			return reconciler.ExternalObservation{
+				ResourceExists:   true,
+				ResourceUpToDate: true,
 			}, nil
 		}
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: false,
+			Diff:             fmt.Sprintf("permission: %s (observed: %s)", want, inv.Permissions),
+		}, nil
+	}
+
+	if inv != nil {
+		e.log.Debug("Collaborator invitation expired", "org", spec.Org, "repo", spec.Repo, "username", spec.Username, "id", inv.ID)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "InvitationExpired", "Invitation of '%s' to collaborate on '%s/%s' expired", spec.Username, spec.Org, spec.Repo)
 	}
 
 	e.log.Debug("Collaborator does not exists", "org", spec.Org, "repo", spec.Repo, "username", spec.Username)
@@ -168,18 +210,69 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) error {
 
 	spec := cr.Spec.DeepCopy()
 
-	err := e.ghCli.Collaborators().Create(spec)
+	// An expired invitation must be cancelled before inviting the user again.
+	expired, err := e.ghCli.Collaborators().FindInvitation(spec)
 	if err != nil {
 		return err
 	}
+	if expired != nil && expired.Expired {
+		err := e.ghCli.Collaborators().DeleteInvitation(spec, expired.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	permission, err := e.permission(cr)
+	if err != nil {
+		return err
+	}
+
+	inv, err := e.ghCli.Collaborators().Create(spec, permission)
+	if err != nil {
+		return err
+	}
+	if inv != nil {
+		e.log.Debug("Collaborator invited", "org", spec.Org, "repo", spec.Repo, "username", spec.Username, "id", inv.ID)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "CollaboratorInvited", "Collaborator '%s/%s/%s' invited", spec.Org, spec.Repo, spec.Username)
+		return nil
+	}
 	e.log.Debug("Collaborator created", "org", spec.Org, "repo", spec.Repo, "username", spec.Username)
 	e.rec.Eventf(cr, corev1.EventTypeNormal, "CollaboratorCreated", "Collaborator '%s/%s/%s' created", spec.Org, spec.Repo, spec.Username)
 
 	return nil
 }
 
+// Update changes the permission of the collaborator or, while the user has
+// not accepted yet, of the pending invitation.
 func (e *external) Update(ctx context.Context, mg resource.Managed) error {
-	return e.Create(ctx, mg)
+	cr, ok := mg.(*collaboratorv1alpha1.Collaborator)
+	if !ok {
+		return errors.New(errNotCollaborator)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	inv, err := e.ghCli.Collaborators().FindInvitation(spec)
+	if err != nil {
+		return err
+	}
+	if inv == nil || inv.Expired {
+		return e.Create(ctx, mg)
+	}
+
+	want, err := e.permission(cr)
+	if err != nil {
+		return err
+	}
+
+	err = e.ghCli.Collaborators().UpdateInvitation(spec, inv.ID, want)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Collaborator invitation updated", "org", spec.Org, "repo", spec.Repo, "username", spec.Username, "id", inv.ID)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "InvitationUpdated", "Invitation of '%s' to collaborate on '%s/%s' updated", spec.Username, spec.Org, spec.Repo)
+
+	return nil
 }
 
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -192,7 +285,20 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 
 	spec := cr.Spec.DeepCopy()
 
-	err := e.ghCli.Collaborators().Delete(spec)
+	inv, err := e.ghCli.Collaborators().FindInvitation(spec)
+	if err != nil {
+		return err
+	}
+	if inv != nil {
+		err := e.ghCli.Collaborators().DeleteInvitation(spec, inv.ID)
+		if err != nil {
+			return err
+		}
+		e.log.Debug("Collaborator invitation cancelled", "org", spec.Org, "repo", spec.Repo, "username", spec.Username, "id", inv.ID)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "InvitationCancelled", "Invitation of '%s' to collaborate on '%s/%s' cancelled", spec.Username, spec.Org, spec.Repo)
+	}
+
+	err = e.ghCli.Collaborators().Delete(spec)
 	if err != nil {
 		return err
 	}
@@ -201,3 +307,21 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 
 	return nil
 }
+
+// permission returns the permission to grant the collaborator. The type of
+// the owner of the repository is resolved once and recorded in the status.
+func (e *external) permission(cr *collaboratorv1alpha1.Collaborator) (string, error) {
+	if cr.Status.OwnerType == nil {
+		org, err := e.ghCli.Collaborators().IsOrg(cr.Spec.Org)
+		if err != nil {
+			return "", err
+		}
+		ownerType := collaboratorv1alpha1.OwnerTypeUser
+		if org {
+			ownerType = collaboratorv1alpha1.OwnerTypeOrganization
+		}
+		cr.Status.OwnerType = ptr.To(ownerType)
+	}
+
+	return github.GrantedPermission(&cr.Spec, *cr.Status.OwnerType == collaboratorv1alpha1.OwnerTypeOrganization), nil
+}
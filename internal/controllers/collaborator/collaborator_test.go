@@ -0,0 +1,62 @@
+package collaborator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+	"k8s.io/client-go/tools/record"
+
+	collaboratorv1alpha1 "github.com/krateoplatformops/github-provider/apis/collaborator/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+)
+
+func TestPermission(t *testing.T) {
+	tests := []struct {
+		name      string
+		org       string
+		ownerType string
+		expected  string
+	}{
+		{name: "organization", org: "acme", ownerType: collaboratorv1alpha1.OwnerTypeOrganization, expected: "maintain"},
+		{name: "user", org: "octocat", ownerType: collaboratorv1alpha1.OwnerTypeUser, expected: "push"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lookups := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				lookups++
+				if r.URL.Path != "/orgs/acme" {
+					http.NotFound(w, r)
+				}
+			}))
+			defer srv.Close()
+
+			e := &external{
+				log:   logging.NewNopLogger(),
+				ghCli: github.NewClient(github.ClientOpts{ApiURL: srv.URL + "/", Token: "token", HttpClient: srv.Client()}),
+				rec:   record.NewFakeRecorder(10),
+			}
+			cr := &collaboratorv1alpha1.Collaborator{Spec: collaboratorv1alpha1.CollaboratorSpec{Org: tc.org, Repo: "web", Username: "hubot", Permission: "maintain"}}
+
+			for range 2 {
+				got, err := e.permission(cr)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != tc.expected {
+					t.Fatalf("expected %q, got %q", tc.expected, got)
+				}
+			}
+			if lookups != 1 {
+				t.Fatalf("expected the owner to be looked up once, got %d lookups", lookups)
+			}
+			if ptr.Deref(cr.Status.OwnerType, "") != tc.ownerType {
+				t.Fatalf("expected the owner type %q, got %v", tc.ownerType, cr.Status.OwnerType)
+			}
+		})
+	}
+}
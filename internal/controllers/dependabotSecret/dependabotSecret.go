@@ -0,0 +1,38 @@
+package dependabotSecret
+
+import (
+	"github.com/krateoplatformops/provider-runtime/pkg/controller"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	secretv1alpha1 "github.com/krateoplatformops/github-provider/apis/secret/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+	"github.com/krateoplatformops/github-provider/internal/controllers/secret"
+)
+
+// Setup adds a controller that reconciles DependabotSecret managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	return secret.Setup(mgr, o, secret.Store[*secretv1alpha1.DependabotSecret]{
+		GroupKind:        secretv1alpha1.DependabotSecretGroupKind,
+		GroupVersionKind: secretv1alpha1.DependabotSecretGroupVersionKind,
+		Service:          (*github.Client).DependabotSecrets,
+		Connection:       connection,
+		Scope:            scopeOf,
+	})
+}
+
+func connection(cr *secretv1alpha1.DependabotSecret) secret.Connection {
+	return secret.Connection{
+		ApiUrl:      cr.Spec.ApiUrl,
+		Credentials: cr.Spec.Credentials,
+		Verbose:     cr.Spec.Verbose,
+	}
+}
+
+// scopeOf returns the owner of the secret: the organization or the repository.
+func scopeOf(cr *secretv1alpha1.DependabotSecret) github.Scope {
+	return github.Scope{
+		Org:   cr.Spec.Org,
+		Owner: cr.Spec.Owner,
+		Repo:  cr.Spec.Repo,
+	}
+}
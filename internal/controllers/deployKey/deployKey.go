@@ -0,0 +1,439 @@
+package deployKey
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/controller"
+	"github.com/krateoplatformops/provider-runtime/pkg/ratelimiter"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/event"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/reconciler"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+
+	deployKeyv1alpha1 "github.com/krateoplatformops/github-provider/apis/deployKey/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const (
+	errNotDeployKey = "managed resource is not a deployKey custom resource"
+)
+
+// Setup adds a controller that reconciles Token managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := reconciler.ControllerName(deployKeyv1alpha1.DeployKeyGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+
+	recorder := mgr.GetEventRecorderFor(name)
+
+	r := reconciler.NewReconciler(mgr,
+		resource.ManagedKind(deployKeyv1alpha1.DeployKeyGroupVersionKind),
+		reconciler.WithExternalConnecter(&connector{
+			kube:     mgr.GetClient(),
+			log:      log,
+			recorder: recorder,
+		}),
+		reconciler.WithPollInterval(o.PollInterval),
+		reconciler.WithLogger(log),
+		reconciler.WithRecorder(event.NewAPIRecorder(recorder)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&deployKeyv1alpha1.DeployKey{}).
+		Complete(ratelimiter.New(name, r, o.GlobalRateLimiter))
+}
+
+type connector struct {
+	kube     client.Client
+	log      logging.Logger
+	recorder record.EventRecorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (reconciler.ExternalClient, error) {
+	cr, ok := mg.(*deployKeyv1alpha1.DeployKey)
+	if !ok {
+		return nil, errors.New(errNotDeployKey)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	csr := spec.Credentials.SecretRef
+	if csr == nil {
+		return nil, fmt.Errorf("no credentials secret referenced")
+	}
+
+	token, err := resource.GetSecret(ctx, c.kube, csr.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := github.ClientOpts{
+		ApiURL: spec.ApiUrl,
+		Token:  token,
+	}
+	opts.HttpClient = clients.DefaultHttpClient()
+
+	if ptr.Deref(cr.Spec.Verbose, false) {
+		opts.HttpClient = &http.Client{
+			Transport: &clients.VerboseTracer{RoundTripper: http.DefaultTransport},
+			Timeout:   50 * time.Second,
+		}
+	}
+
+	return &external{
+		kube:  c.kube,
+		log:   c.log,
+		ghCli: github.NewClient(opts),
+		rec:   c.recorder,
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube  client.Client
+	log   logging.Logger
+	ghCli *github.Client
+	rec   record.EventRecorder
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	return nil // NOOP
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (reconciler.ExternalObservation, error) {
+	cr, ok := mg.(*deployKeyv1alpha1.DeployKey)
+	if !ok {
+		return reconciler.ExternalObservation{}, errors.New(errNotDeployKey)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	key, err := e.publicKey(ctx, spec)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	var dk *github.DeployKey
+	if id := deployKeyID(cr); id != 0 {
+		dk, err = e.ghCli.DeployKeys().Get(spec, id)
+	} else if len(key) > 0 {
+		dk, err = e.ghCli.DeployKeys().Find(spec, key)
+	}
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	if dk == nil {
+		e.log.Debug("Deploy key not found", "owner", spec.Owner, "repo", spec.Repo, "title", spec.Title)
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	cr.Status.Id = ptr.To(dk.ID)
+	cr.Status.Verified = ptr.To(dk.Verified)
+
+	lateInitialized := false
+	if id := strconv.FormatInt(dk.ID, 10); meta.GetExternalName(cr) != id {
+		meta.SetExternalName(cr, id)
+		lateInitialized = true
+	}
+
+	cr.SetConditions(prv1.Available())
+
+	diff := []string{}
+	if dk.Title != spec.Title {
+		diff = append(diff, fmt.Sprintf("title: %s (observed: %s)", spec.Title, dk.Title))
+	}
+	if readOnly := ptr.Deref(spec.ReadOnly, true); dk.ReadOnly != readOnly {
+		diff = append(diff, fmt.Sprintf("readOnly: %t (observed: %t)", readOnly, dk.ReadOnly))
+	}
+	if !dk.HasKey(key) {
+		diff = append(diff, "publicKey: changed")
+	}
+	if len(diff) > 0 {
+		e.log.Debug("Deploy key is not up to date", "owner", spec.Owner, "repo", spec.Repo, "id", dk.ID, "diff", diff)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "NotUpToDate", "Deploy key '%s' of repo '%s/%s' differs from desired state: %s", spec.Title, spec.Owner, spec.Repo, strings.Join(diff, ", "))
+
+		return reconciler.ExternalObservation{
+			ResourceExists:          true,
+			ResourceUpToDate:        false,
+			ResourceLateInitialized: lateInitialized,
+			Diff:                    strings.Join(diff, "\n"),
+		}, nil
+	}
+
+	e.log.Debug("Deploy key already exists", "owner", spec.Owner, "repo", spec.Repo, "id", dk.ID)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "AlreadyExists", "Deploy key '%s' of repo '%s/%s' already exists", spec.Title, spec.Owner, spec.Repo)
+
+	return reconciler.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        true,
+		ResourceLateInitialized: lateInitialized,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*deployKeyv1alpha1.DeployKey)
+	if !ok {
+		return errors.New(errNotDeployKey)
+	}
+
+	cr.SetConditions(prv1.Creating())
+
+	return e.register(ctx, cr)
+}
+
+// Update replaces the deploy key, since deploy keys cannot be edited.
+func (e *external) Update(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*deployKeyv1alpha1.DeployKey)
+	if !ok {
+		return errors.New(errNotDeployKey)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	if id := deployKeyID(cr); id != 0 {
+		err := e.ghCli.DeployKeys().Delete(spec, id)
+		if err != nil {
+			return err
+		}
+		e.log.Debug("Deploy key deleted for replacement", "owner", spec.Owner, "repo", spec.Repo, "id", id)
+	}
+
+	return e.register(ctx, cr)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*deployKeyv1alpha1.DeployKey)
+	if !ok {
+		return errors.New(errNotDeployKey)
+	}
+
+	cr.SetConditions(prv1.Deleting())
+
+	spec := cr.Spec.DeepCopy()
+
+	id := deployKeyID(cr)
+	if id == 0 {
+		return nil
+	}
+
+	err := e.ghCli.DeployKeys().Delete(spec, id)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Deploy key deleted", "owner", spec.Owner, "repo", spec.Repo, "id", id)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "DeployKeyDeleted", "Deploy key '%s' of repo '%s/%s' deleted", spec.Title, spec.Owner, spec.Repo)
+
+	return nil
+}
+
+// register adds the desired public key to the repository, generating the
+// key pair first when needed, and tracks the new key by its id.
+func (e *external) register(ctx context.Context, cr *deployKeyv1alpha1.DeployKey) error {
+	spec := cr.Spec.DeepCopy()
+
+	key, err := e.publicKey(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	if len(key) == 0 {
+		if spec.PublicKeyRef != nil {
+			return fmt.Errorf("public key secret '%s/%s' has no key '%s'",
+				spec.PublicKeyRef.Namespace, spec.PublicKeyRef.Name, spec.PublicKeyRef.Key)
+		}
+
+		key, err = e.generateKeyPair(ctx, cr)
+		if err != nil {
+			return err
+		}
+	}
+
+	dk, err := e.ghCli.DeployKeys().Create(spec, key)
+	if err != nil {
+		return err
+	}
+
+	cr.Status.Id = ptr.To(dk.ID)
+	cr.Status.Verified = ptr.To(dk.Verified)
+	meta.SetExternalName(cr, strconv.FormatInt(dk.ID, 10))
+
+	e.log.Debug("Deploy key registered", "owner", spec.Owner, "repo", spec.Repo, "id", dk.ID)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "DeployKeyCreated", "Deploy key '%s' of repo '%s/%s' created", spec.Title, spec.Owner, spec.Repo)
+
+	return nil
+}
+
+// publicKey returns the desired public key, either read from the
+// referenced Secret or from the generated key pair. It returns an empty
+// string when the key pair has not been generated yet.
+func (e *external) publicKey(ctx context.Context, spec *deployKeyv1alpha1.DeployKeySpec) (string, error) {
+	if spec.PublicKeyRef != nil {
+		key, err := resource.GetSecret(ctx, e.kube, spec.PublicKeyRef.DeepCopy())
+		return strings.TrimSpace(key), err
+	}
+
+	ref := spec.WriteConnectionSecretToRef
+	if ref == nil {
+		return "", fmt.Errorf("either publicKeyRef or writeConnectionSecretToRef must be set")
+	}
+
+	secret := &corev1.Secret{}
+	err := e.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if len(secret.Data[deployKeyv1alpha1.ConnectionKeyPrivateKey]) == 0 {
+		return "", nil
+	}
+
+	return strings.TrimSpace(string(secret.Data[deployKeyv1alpha1.ConnectionKeyPublicKey])), nil
+}
+
+// generateKeyPair generates an ed25519 key pair, writes it to the
+// connection Secret and returns the public key.
+func (e *external) generateKeyPair(ctx context.Context, cr *deployKeyv1alpha1.DeployKey) (string, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+
+	comment := fmt.Sprintf("%s/%s", cr.Spec.Owner, cr.Spec.Repo)
+	publicKey := marshalAuthorizedKey(pub, comment)
+	privateKey, err := marshalPrivateKey(pub, priv, comment)
+	if err != nil {
+		return "", err
+	}
+
+	ref := cr.Spec.WriteConnectionSecretToRef
+
+	secret := &corev1.Secret{}
+	err = e.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", err
+	}
+	exists := err == nil
+
+	secret.Name, secret.Namespace = ref.Name, ref.Namespace
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[deployKeyv1alpha1.ConnectionKeyPrivateKey] = privateKey
+	secret.Data[deployKeyv1alpha1.ConnectionKeyPublicKey] = []byte(publicKey)
+
+	if exists {
+		err = e.kube.Update(ctx, secret)
+	} else {
+		// Owner references cannot cross namespaces.
+		if ref.Namespace == cr.Namespace {
+			secret.OwnerReferences = []metav1.OwnerReference{
+				*metav1.NewControllerRef(cr, deployKeyv1alpha1.DeployKeyGroupVersionKind),
+			}
+		}
+		err = e.kube.Create(ctx, secret)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	e.log.Debug("Key pair generated", "secret", ref.Name, "namespace", ref.Namespace)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "KeyPairGenerated", "Key pair written to secret '%s/%s'", ref.Namespace, ref.Name)
+
+	return publicKey, nil
+}
+
+// deployKeyID returns the id of the deploy key, stored in the status and
+// in the external name, or 0 if it is not known yet.
+func deployKeyID(cr *deployKeyv1alpha1.DeployKey) int64 {
+	if id := ptr.Deref(cr.Status.Id, 0); id != 0 {
+		return id
+	}
+	if id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64); err == nil {
+		return id
+	}
+	return 0
+}
+
+// marshalAuthorizedKey encodes an ed25519 public key in the OpenSSH
+// authorized_keys format.
+func marshalAuthorizedKey(pub ed25519.PublicKey, comment string) string {
+	blob := sshString(nil, []byte(sshKeyType))
+	blob = sshString(blob, pub)
+
+	return fmt.Sprintf("%s %s %s", sshKeyType, base64.StdEncoding.EncodeToString(blob), comment)
+}
+
+// marshalPrivateKey encodes an unencrypted ed25519 private key in the
+// OpenSSH private key format.
+//
+// Format docs: https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.key
+func marshalPrivateKey(pub ed25519.PublicKey, priv ed25519.PrivateKey, comment string) ([]byte, error) {
+	check := make([]byte, 4)
+	if _, err := rand.Read(check); err != nil {
+		return nil, err
+	}
+
+	pubBlob := sshString(nil, []byte(sshKeyType))
+	pubBlob = sshString(pubBlob, pub)
+
+	private := append(check, check...)
+	private = sshString(private, []byte(sshKeyType))
+	private = sshString(private, pub)
+	private = sshString(private, priv)
+	private = sshString(private, []byte(comment))
+	for i := byte(1); len(private)%8 != 0; i++ {
+		private = append(private, i)
+	}
+
+	data := []byte("openssh-key-v1\x00")
+	data = sshString(data, []byte("none"))
+	data = sshString(data, []byte("none"))
+	data = sshString(data, nil)
+	data = binary.BigEndian.AppendUint32(data, 1)
+	data = sshString(data, pubBlob)
+	data = sshString(data, private)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "OPENSSH PRIVATE KEY", Bytes: data}), nil
+}
+
+const sshKeyType = "ssh-ed25519"
+
+// sshString appends s to b as an SSH wire format string.
+func sshString(b, s []byte) []byte {
+	b = binary.BigEndian.AppendUint32(b, uint32(len(s)))
+	return append(b, s...)
+}
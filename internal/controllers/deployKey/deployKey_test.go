@@ -0,0 +1,75 @@
+package deployKey
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+
+	deployKeyv1alpha1 "github.com/krateoplatformops/github-provider/apis/deployKey/v1alpha1"
+)
+
+func TestDeployKeyID(t *testing.T) {
+	tests := []struct {
+		name         string
+		externalName string
+		status       *int64
+		expected     int64
+	}{
+		{name: "unknown", expected: 0},
+		{name: "status", externalName: "42", status: ptr.To(int64(7)), expected: 7},
+		{name: "external name", externalName: "42", expected: 42},
+		{name: "external name of another kind", externalName: "deploy-key", expected: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := &deployKeyv1alpha1.DeployKey{}
+			cr.Status.Id = tc.status
+			if len(tc.externalName) > 0 {
+				meta.SetExternalName(cr, tc.externalName)
+			}
+
+			if got := deployKeyID(cr); got != tc.expected {
+				t.Fatalf("expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestMarshalKeys(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blob := sshString(sshString(nil, []byte(sshKeyType)), pub)
+
+	fields := strings.Fields(marshalAuthorizedKey(pub, "deploy@acme"))
+	if len(fields) != 3 || fields[0] != sshKeyType || fields[2] != "deploy@acme" {
+		t.Fatalf("unexpected authorized key %q", fields)
+	}
+	if got, err := base64.StdEncoding.DecodeString(fields[1]); err != nil || !bytes.Equal(got, blob) {
+		t.Fatalf("unexpected public key blob %q (%v)", fields[1], err)
+	}
+
+	data, err := marshalPrivateKey(pub, priv, "deploy@acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "OPENSSH PRIVATE KEY" {
+		t.Fatalf("unexpected private key %q", data)
+	}
+	if !bytes.HasPrefix(block.Bytes, []byte("openssh-key-v1\x00")) {
+		t.Fatalf("missing the openssh magic")
+	}
+	if !bytes.Contains(block.Bytes, sshString(nil, blob)) || !bytes.Contains(block.Bytes, sshString(nil, priv)) {
+		t.Fatalf("the private key does not hold the key pair")
+	}
+}
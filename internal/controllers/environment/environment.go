@@ -0,0 +1,333 @@
+package environment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/controller"
+	"github.com/krateoplatformops/provider-runtime/pkg/ratelimiter"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/event"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/reconciler"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+
+	environmentv1alpha1 "github.com/krateoplatformops/github-provider/apis/environment/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const (
+	errNotEnvironment = "managed resource is not an environment custom resource"
+)
+
+// Setup adds a controller that reconciles Token managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := reconciler.ControllerName(environmentv1alpha1.EnvironmentGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+
+	recorder := mgr.GetEventRecorderFor(name)
+
+	r := reconciler.NewReconciler(mgr,
+		resource.ManagedKind(environmentv1alpha1.EnvironmentGroupVersionKind),
+		reconciler.WithExternalConnecter(&connector{
+			kube:     mgr.GetClient(),
+			log:      log,
+			recorder: recorder,
+		}),
+		reconciler.WithPollInterval(o.PollInterval),
+		reconciler.WithLogger(log),
+		reconciler.WithRecorder(event.NewAPIRecorder(recorder)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&environmentv1alpha1.Environment{}).
+		Complete(ratelimiter.New(name, r, o.GlobalRateLimiter))
+}
+
+type connector struct {
+	kube     client.Client
+	log      logging.Logger
+	recorder record.EventRecorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (reconciler.ExternalClient, error) {
+	cr, ok := mg.(*environmentv1alpha1.Environment)
+	if !ok {
+		return nil, errors.New(errNotEnvironment)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	csr := spec.Credentials.SecretRef
+	if csr == nil {
+		return nil, fmt.Errorf("no credentials secret referenced")
+	}
+
+	token, err := resource.GetSecret(ctx, c.kube, csr.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := github.ClientOpts{
+		ApiURL: spec.ApiUrl,
+		Token:  token,
+	}
+	opts.HttpClient = clients.DefaultHttpClient()
+
+	if ptr.Deref(cr.Spec.Verbose, false) {
+		opts.HttpClient = &http.Client{
+			Transport: &clients.VerboseTracer{RoundTripper: http.DefaultTransport},
+			Timeout:   50 * time.Second,
+		}
+	}
+
+	return &external{
+		kube:  c.kube,
+		log:   c.log,
+		ghCli: github.NewClient(opts),
+		rec:   c.recorder,
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube  client.Client
+	log   logging.Logger
+	ghCli *github.Client
+	rec   record.EventRecorder
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	return nil // NOOP
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (reconciler.ExternalObservation, error) {
+	cr, ok := mg.(*environmentv1alpha1.Environment)
+	if !ok {
+		return reconciler.ExternalObservation{}, errors.New(errNotEnvironment)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	if err := validate(spec); err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	env, err := e.ghCli.Environments().Get(spec)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	if env == nil {
+		e.log.Debug("Environment not found", "owner", spec.Owner, "repo", spec.Repo, "name", spec.Name)
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	cr.Status.Id = ptr.To(env.ID)
+
+	cr.SetConditions(prv1.Available())
+
+	diff := env.Diff(spec)
+	if isCustom(spec) {
+		policies, err := e.ghCli.Environments().ListBranchPolicies(spec)
+		if err != nil {
+			return reconciler.ExternalObservation{}, err
+		}
+		diff = append(diff, github.DiffBranchPolicies(spec.BranchPolicies, policies)...)
+	}
+	if len(diff) > 0 {
+		e.log.Debug("Environment is not up to date", "owner", spec.Owner, "repo", spec.Repo, "name", spec.Name, "diff", diff)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "NotUpToDate", "Environment '%s' of repo '%s/%s' differs from desired state: %s", spec.Name, spec.Owner, spec.Repo, strings.Join(diff, ", "))
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: false,
+			Diff:             strings.Join(diff, "\n"),
+		}, nil
+	}
+
+	e.log.Debug("Environment already exists", "owner", spec.Owner, "repo", spec.Repo, "name", spec.Name)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "AlreadyExists", "Environment '%s' of repo '%s/%s' already exists", spec.Name, spec.Owner, spec.Repo)
+
+	return reconciler.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*environmentv1alpha1.Environment)
+	if !ok {
+		return errors.New(errNotEnvironment)
+	}
+
+	cr.SetConditions(prv1.Creating())
+
+	spec := cr.Spec.DeepCopy()
+
+	err := e.put(spec)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Environment created", "owner", spec.Owner, "repo", spec.Repo, "name", spec.Name)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "EnvironmentCreated", "Environment '%s' of repo '%s/%s' created", spec.Name, spec.Owner, spec.Repo)
+
+	return nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*environmentv1alpha1.Environment)
+	if !ok {
+		return errors.New(errNotEnvironment)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	err := e.put(spec)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Environment updated", "owner", spec.Owner, "repo", spec.Repo, "name", spec.Name)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "EnvironmentUpdated", "Environment '%s' of repo '%s/%s' updated", spec.Name, spec.Owner, spec.Repo)
+
+	return nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*environmentv1alpha1.Environment)
+	if !ok {
+		return errors.New(errNotEnvironment)
+	}
+
+	cr.SetConditions(prv1.Deleting())
+
+	spec := cr.Spec.DeepCopy()
+
+	err := e.ghCli.Environments().Delete(spec)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Environment deleted", "owner", spec.Owner, "repo", spec.Repo, "name", spec.Name)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "EnvironmentDeleted", "Environment '%s' of repo '%s/%s' deleted", spec.Name, spec.Owner, spec.Repo)
+
+	return nil
+}
+
+// put creates or updates the environment, then adds the missing branch
+// policies and removes the ones no longer desired.
+func (e *external) put(spec *environmentv1alpha1.EnvironmentSpec) error {
+	if err := validate(spec); err != nil {
+		return err
+	}
+
+	reviewers, err := e.reviewerIDs(spec)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.ghCli.Environments().Put(spec, reviewers)
+	if err != nil {
+		return err
+	}
+
+	if !isCustom(spec) {
+		return nil
+	}
+
+	policies, err := e.ghCli.Environments().ListBranchPolicies(spec)
+	if err != nil {
+		return err
+	}
+
+	want := map[string]bool{}
+	for _, p := range spec.BranchPolicies {
+		want[github.BranchPolicyKey(ptr.Deref(p.Type, ""), p.Name)] = true
+	}
+	got := map[string]bool{}
+	for _, p := range policies {
+		key := github.BranchPolicyKey(p.Type, p.Name)
+		got[key] = true
+		if want[key] {
+			continue
+		}
+		err := e.ghCli.Environments().DeleteBranchPolicy(spec, p.ID)
+		if err != nil {
+			return err
+		}
+		e.log.Debug("Branch policy deleted", "environment", spec.Name, "policy", p.Name, "type", p.Type)
+	}
+	for i := range spec.BranchPolicies {
+		p := &spec.BranchPolicies[i]
+		if got[github.BranchPolicyKey(ptr.Deref(p.Type, ""), p.Name)] {
+			continue
+		}
+		err := e.ghCli.Environments().CreateBranchPolicy(spec, p)
+		if err != nil {
+			return err
+		}
+		e.log.Debug("Branch policy created", "environment", spec.Name, "policy", p.Name, "type", ptr.Deref(p.Type, "branch"))
+	}
+
+	return nil
+}
+
+// reviewerIDs resolves the logins of the users and the slugs of the teams
+// of the reviewers to their ids.
+func (e *external) reviewerIDs(spec *environmentv1alpha1.EnvironmentSpec) ([]github.ReviewerID, error) {
+	ids := make([]github.ReviewerID, 0, len(spec.Reviewers))
+	for _, rv := range spec.Reviewers {
+		switch rv.Type {
+		case environmentv1alpha1.ReviewerTypeTeam:
+			team, err := e.ghCli.Teams().GetBySlug(spec.Owner, rv.Name)
+			if err != nil {
+				return nil, err
+			}
+			if team == nil {
+				return nil, fmt.Errorf("team '%s/%s' not found", spec.Owner, rv.Name)
+			}
+			ids = append(ids, github.ReviewerID{Type: rv.Type, ID: team.ID})
+		default:
+			user, err := e.ghCli.Users().Get(rv.Name)
+			if err != nil {
+				return nil, err
+			}
+			if user == nil {
+				return nil, fmt.Errorf("user '%s' not found", rv.Name)
+			}
+			ids = append(ids, github.ReviewerID{Type: rv.Type, ID: user.ID})
+		}
+	}
+	return ids, nil
+}
+
+func validate(spec *environmentv1alpha1.EnvironmentSpec) error {
+	if len(spec.BranchPolicies) > 0 && !isCustom(spec) {
+		return fmt.Errorf("branchPolicies require the %s deployment branch policy", environmentv1alpha1.DeploymentBranchPolicyCustomBranchPolicies)
+	}
+	return nil
+}
+
+// isCustom reports whether the branches that can deploy are selected by
+// the branch policies.
+func isCustom(spec *environmentv1alpha1.EnvironmentSpec) bool {
+	return ptr.Deref(spec.DeploymentBranchPolicy, "") == environmentv1alpha1.DeploymentBranchPolicyCustomBranchPolicies
+}
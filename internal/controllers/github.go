@@ -7,6 +7,21 @@ import (
 	"github.com/krateoplatformops/github-provider/internal/controllers/repo"
 	"github.com/krateoplatformops/github-provider/internal/controllers/collaborator"
 	"github.com/krateoplatformops/github-provider/internal/controllers/teamRepo"
+	"github.com/krateoplatformops/github-provider/internal/controllers/branchProtection"
+	"github.com/krateoplatformops/github-provider/internal/controllers/repositoryRuleset"
+	"github.com/krateoplatformops/github-provider/internal/controllers/organizationRuleset"
+	"github.com/krateoplatformops/github-provider/internal/controllers/repoWebhook"
+	"github.com/krateoplatformops/github-provider/internal/controllers/orgWebhook"
+	"github.com/krateoplatformops/github-provider/internal/controllers/deployKey"
+	"github.com/krateoplatformops/github-provider/internal/controllers/actionsSecret"
+	"github.com/krateoplatformops/github-provider/internal/controllers/dependabotSecret"
+	"github.com/krateoplatformops/github-provider/internal/controllers/codespacesSecret"
+	"github.com/krateoplatformops/github-provider/internal/controllers/actionsVariable"
+	"github.com/krateoplatformops/github-provider/internal/controllers/environment"
+	"github.com/krateoplatformops/github-provider/internal/controllers/team"
+	"github.com/krateoplatformops/github-provider/internal/controllers/teamMembership"
+	"github.com/krateoplatformops/github-provider/internal/controllers/teamMembers"
+	"github.com/krateoplatformops/github-provider/internal/controllers/orgMembership"
 )
 
 // Setup creates all controllers with the supplied logger and adds them to
@@ -16,6 +31,21 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		repo.Setup,
 		collaborator.Setup,
 		teamRepo.Setup,
+		branchProtection.Setup,
+		repositoryRuleset.Setup,
+		organizationRuleset.Setup,
+		repoWebhook.Setup,
+		orgWebhook.Setup,
+		deployKey.Setup,
+		actionsSecret.Setup,
+		dependabotSecret.Setup,
+		codespacesSecret.Setup,
+		actionsVariable.Setup,
+		environment.Setup,
+		team.Setup,
+		teamMembership.Setup,
+		teamMembers.Setup,
+		orgMembership.Setup,
 	} {
 		if err := setup(mgr, o); err != nil {
 			return err
@@ -0,0 +1,407 @@
+package orgMembership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/controller"
+	"github.com/krateoplatformops/provider-runtime/pkg/ratelimiter"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/event"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/reconciler"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+
+	orgMembershipv1alpha1 "github.com/krateoplatformops/github-provider/apis/orgMembership/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const (
+	errNotOrgMembership = "managed resource is not an orgMembership custom resource"
+)
+
+// Setup adds a controller that reconciles Token managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := reconciler.ControllerName(orgMembershipv1alpha1.OrgMembershipGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+
+	recorder := mgr.GetEventRecorderFor(name)
+
+	r := reconciler.NewReconciler(mgr,
+		resource.ManagedKind(orgMembershipv1alpha1.OrgMembershipGroupVersionKind),
+		reconciler.WithExternalConnecter(&connector{
+			kube:     mgr.GetClient(),
+			log:      log,
+			recorder: recorder,
+		}),
+		reconciler.WithPollInterval(o.PollInterval),
+		reconciler.WithLogger(log),
+		reconciler.WithRecorder(event.NewAPIRecorder(recorder)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&orgMembershipv1alpha1.OrgMembership{}).
+		Complete(ratelimiter.New(name, r, o.GlobalRateLimiter))
+}
+
+type connector struct {
+	kube     client.Client
+	log      logging.Logger
+	recorder record.EventRecorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (reconciler.ExternalClient, error) {
+	cr, ok := mg.(*orgMembershipv1alpha1.OrgMembership)
+	if !ok {
+		return nil, errors.New(errNotOrgMembership)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	csr := spec.Credentials.SecretRef
+	if csr == nil {
+		return nil, fmt.Errorf("no credentials secret referenced")
+	}
+
+	token, err := resource.GetSecret(ctx, c.kube, csr.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := github.ClientOpts{
+		ApiURL: spec.ApiUrl,
+		Token:  token,
+	}
+	opts.HttpClient = clients.DefaultHttpClient()
+
+	if ptr.Deref(cr.Spec.Verbose, false) {
+		opts.HttpClient = &http.Client{
+			Transport: &clients.VerboseTracer{RoundTripper: http.DefaultTransport},
+			Timeout:   50 * time.Second,
+		}
+	}
+
+	return &external{
+		kube:  c.kube,
+		log:   c.log,
+		ghCli: github.NewClient(opts),
+		rec:   c.recorder,
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube  client.Client
+	log   logging.Logger
+	ghCli *github.Client
+	rec   record.EventRecorder
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	return nil // NOOP
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (reconciler.ExternalObservation, error) {
+	cr, ok := mg.(*orgMembershipv1alpha1.OrgMembership)
+	if !ok {
+		return reconciler.ExternalObservation{}, errors.New(errNotOrgMembership)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	invitee, err := inviteeOf(spec)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	if spec.Username != nil {
+		m, err := e.ghCli.OrgMemberships().Get(spec.Org, *spec.Username)
+		if err != nil {
+			return reconciler.ExternalObservation{}, err
+		}
+		if m != nil && m.State == orgMembershipv1alpha1.StateActive {
+			cr.Status.State = ptr.To(m.State)
+			cr.Status.Role = ptr.To(m.Role)
+			cr.SetConditions(prv1.Available())
+
+			if role := ptr.Deref(spec.Role, orgMembershipv1alpha1.RoleMember); m.Role != role {
+				e.log.Debug("Org membership is not up to date", "org", spec.Org, "invitee", invitee, "role", m.Role)
+				e.rec.Eventf(cr, corev1.EventTypeNormal, "NotUpToDate", "Membership of '%s' in org '%s' differs from desired state: role: %s (observed: %s)", invitee, spec.Org, role, m.Role)
+
+				return reconciler.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+					Diff:             fmt.Sprintf("role: %s (observed: %s)", role, m.Role),
+				}, nil
+			}
+
+			e.log.Debug("Org membership already exists", "org", spec.Org, "invitee", invitee)
+			e.rec.Eventf(cr, corev1.EventTypeNormal, "AlreadyExists", "Membership of '%s' in org '%s' already exists", invitee, spec.Org)
+
+			return reconciler.ExternalObservation{
+				ResourceExists:   true,
+				ResourceUpToDate: true,
+			}, nil
+		}
+	}
+
+	inv, err := e.ghCli.OrgMemberships().FindInvitation(spec)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+	if inv != nil {
+		cr.Status.State = ptr.To(orgMembershipv1alpha1.StatePending)
+		cr.Status.InvitationId = ptr.To(inv.ID)
+		cr.Status.Role = ptr.To(inv.OrgRole())
+		cr.SetConditions(orgMembershipv1alpha1.Pending())
+
+		lateInitialized := false
+		if id := strconv.FormatInt(inv.ID, 10); meta.GetExternalName(cr) != id {
+			meta.SetExternalName(cr, id)
+			lateInitialized = true
+		}
+
+		if role := ptr.Deref(spec.Role, orgMembershipv1alpha1.RoleMember); inv.OrgRole() != role {
+			e.log.Debug("Org invitation is not up to date", "org", spec.Org, "invitee", invitee, "role", inv.OrgRole())
+			e.rec.Eventf(cr, corev1.EventTypeNormal, "NotUpToDate", "Invitation of '%s' to org '%s' differs from desired state: role: %s (observed: %s)", invitee, spec.Org, role, inv.OrgRole())
+
+			return reconciler.ExternalObservation{
+				ResourceExists:          true,
+				ResourceUpToDate:        false,
+				ResourceLateInitialized: lateInitialized,
+				Diff:                    fmt.Sprintf("role: %s (observed: %s)", role, inv.OrgRole()),
+			}, nil
+		}
+
+		e.log.Debug("Org invitation pending", "org", spec.Org, "invitee", invitee, "id", inv.ID)
+
+		return reconciler.ExternalObservation{
+			ResourceExists:          true,
+			ResourceUpToDate:        true,
+			ResourceLateInitialized: lateInitialized,
+		}, nil
+	}
+
+	failed, err := e.ghCli.OrgMemberships().FindFailedInvitation(spec)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+	if failed != nil && failed.ID >= invitationID(cr) {
+		// Reported as missing, so that the user is invited again.
+		cr.Status.InvitationId = ptr.To(failed.ID)
+		if failed.Expired() {
+			cr.Status.State = ptr.To(orgMembershipv1alpha1.StateExpired)
+
+			e.log.Debug("Org invitation expired", "org", spec.Org, "invitee", invitee, "id", failed.ID)
+			e.rec.Eventf(cr, corev1.EventTypeNormal, "InvitationExpired", "Invitation of '%s' to org '%s' expired", invitee, spec.Org)
+		} else {
+			cr.Status.State = ptr.To(orgMembershipv1alpha1.StateFailed)
+
+			e.log.Debug("Org invitation failed", "org", spec.Org, "invitee", invitee, "id", failed.ID, "reason", failed.FailedReason)
+			e.rec.Eventf(cr, corev1.EventTypeWarning, "InvitationFailed", "Invitation of '%s' to org '%s' failed: %s", invitee, spec.Org, failed.FailedReason)
+		}
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	if spec.Email != nil && invitationID(cr) != 0 {
+		// The invitation is neither pending nor failed: it was accepted by
+		// a user whose login cannot be known from the email address.
+		cr.Status.State = ptr.To(orgMembershipv1alpha1.StateActive)
+		cr.SetConditions(prv1.Available())
+
+		e.log.Debug("Org invitation accepted", "org", spec.Org, "invitee", invitee)
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	e.log.Debug("Org membership not found", "org", spec.Org, "invitee", invitee)
+
+	return reconciler.ExternalObservation{
+		ResourceExists:   false,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*orgMembershipv1alpha1.OrgMembership)
+	if !ok {
+		return errors.New(errNotOrgMembership)
+	}
+
+	cr.SetConditions(prv1.Creating())
+
+	spec := cr.Spec.DeepCopy()
+
+	invitee, err := inviteeOf(spec)
+	if err != nil {
+		return err
+	}
+
+	return e.invite(cr, spec, invitee)
+}
+
+// Update changes the role of an active member. The role of an invitation
+// cannot be changed: a pending invitation is cancelled and sent again.
+func (e *external) Update(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*orgMembershipv1alpha1.OrgMembership)
+	if !ok {
+		return errors.New(errNotOrgMembership)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	if ptr.Deref(cr.Status.State, "") == orgMembershipv1alpha1.StatePending {
+		invitee, err := inviteeOf(spec)
+		if err != nil {
+			return err
+		}
+
+		id := invitationID(cr)
+		if err := e.ghCli.OrgMemberships().CancelInvitation(spec.Org, id); err != nil {
+			return err
+		}
+		e.log.Debug("Org invitation cancelled", "org", spec.Org, "invitee", invitee, "id", id)
+
+		return e.invite(cr, spec, invitee)
+	}
+
+	role := ptr.Deref(spec.Role, orgMembershipv1alpha1.RoleMember)
+	if role == orgMembershipv1alpha1.RoleBillingManager {
+		return fmt.Errorf("the %s role can only be given by an invitation", role)
+	}
+
+	m, err := e.ghCli.OrgMemberships().SetRole(spec.Org, ptr.Deref(spec.Username, ""), role)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Org membership updated", "org", spec.Org, "username", ptr.Deref(spec.Username, ""), "role", m.Role)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "OrgMembershipUpdated", "Membership of '%s' in org '%s' updated", ptr.Deref(spec.Username, ""), spec.Org)
+
+	return nil
+}
+
+// Delete cancels the pending invitation. An active member is only removed
+// from the organization with the remove deletion mode.
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*orgMembershipv1alpha1.OrgMembership)
+	if !ok {
+		return errors.New(errNotOrgMembership)
+	}
+
+	cr.SetConditions(prv1.Deleting())
+
+	spec := cr.Spec.DeepCopy()
+
+	invitee, err := inviteeOf(spec)
+	if err != nil {
+		return err
+	}
+
+	if spec.Username != nil && ptr.Deref(spec.DeletionMode, orgMembershipv1alpha1.DeletionModeCancel) == orgMembershipv1alpha1.DeletionModeRemove {
+		err := e.ghCli.OrgMemberships().Remove(spec.Org, *spec.Username)
+		if err != nil {
+			return err
+		}
+		e.log.Debug("Org membership deleted", "org", spec.Org, "invitee", invitee)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "OrgMembershipDeleted", "Membership of '%s' in org '%s' deleted", invitee, spec.Org)
+
+		return nil
+	}
+
+	inv, err := e.ghCli.OrgMemberships().FindInvitation(spec)
+	if err != nil {
+		return err
+	}
+	if inv == nil {
+		e.log.Debug("No pending invitation to cancel", "org", spec.Org, "invitee", invitee)
+		if spec.Username != nil {
+			e.rec.Eventf(cr, corev1.EventTypeNormal, "OrgMembershipKept", "Membership of '%s' in org '%s' kept: set spec.deletionMode to remove to remove the member", invitee, spec.Org)
+		}
+		return nil
+	}
+
+	err = e.ghCli.OrgMemberships().CancelInvitation(spec.Org, inv.ID)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Org invitation cancelled", "org", spec.Org, "invitee", invitee, "id", inv.ID)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "InvitationCancelled", "Invitation of '%s' to org '%s' cancelled", invitee, spec.Org)
+
+	return nil
+}
+
+// invite sends an invitation to the user or the email address of the spec
+// and records its id as the external name.
+func (e *external) invite(cr *orgMembershipv1alpha1.OrgMembership, spec *orgMembershipv1alpha1.OrgMembershipSpec, invitee string) error {
+	var userID int64
+	if spec.Username != nil {
+		user, err := e.ghCli.Users().Get(*spec.Username)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return fmt.Errorf("user '%s' not found", *spec.Username)
+		}
+		userID = user.ID
+	}
+
+	inv, err := e.ghCli.OrgMemberships().Invite(spec, userID)
+	if err != nil {
+		return err
+	}
+
+	meta.SetExternalName(cr, strconv.FormatInt(inv.ID, 10))
+
+	e.log.Debug("Org invitation created", "org", spec.Org, "invitee", invitee, "id", inv.ID)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "InvitationCreated", "Invitation of '%s' to org '%s' created", invitee, spec.Org)
+
+	return nil
+}
+
+// inviteeOf returns the username or the email address of the spec, only
+// one of which must be set.
+func inviteeOf(spec *orgMembershipv1alpha1.OrgMembershipSpec) (string, error) {
+	switch {
+	case spec.Username != nil && spec.Email != nil:
+		return "", fmt.Errorf("username and email cannot be set at the same time")
+	case spec.Username != nil:
+		return *spec.Username, nil
+	case spec.Email != nil:
+		return *spec.Email, nil
+	default:
+		return "", fmt.Errorf("either username or email must be set")
+	}
+}
+
+// invitationID returns the id of the last invitation recorded in the
+// external name or, failing that, in the status. It returns 0 if no
+// invitation was sent yet.
+func invitationID(cr *orgMembershipv1alpha1.OrgMembership) int64 {
+	if id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64); err == nil {
+		return id
+	}
+	return ptr.Deref(cr.Status.InvitationId, 0)
+}
@@ -0,0 +1,197 @@
+package orgMembership
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+	"k8s.io/client-go/tools/record"
+
+	orgMembershipv1alpha1 "github.com/krateoplatformops/github-provider/apis/orgMembership/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+)
+
+func TestInviteeOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     orgMembershipv1alpha1.OrgMembershipSpec
+		expected string
+		err      bool
+	}{
+		{name: "username", spec: orgMembershipv1alpha1.OrgMembershipSpec{Username: ptr.To("octocat")}, expected: "octocat"},
+		{name: "email", spec: orgMembershipv1alpha1.OrgMembershipSpec{Email: ptr.To("octocat@example.com")}, expected: "octocat@example.com"},
+		{name: "both", spec: orgMembershipv1alpha1.OrgMembershipSpec{Username: ptr.To("octocat"), Email: ptr.To("octocat@example.com")}, err: true},
+		{name: "none", spec: orgMembershipv1alpha1.OrgMembershipSpec{}, err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := inviteeOf(&tc.spec)
+			if (err != nil) != tc.err {
+				t.Fatalf("expected an error: %v, got %v", tc.err, err)
+			}
+			if got != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestInvitationID(t *testing.T) {
+	tests := []struct {
+		name         string
+		externalName string
+		status       *int64
+		expected     int64
+	}{
+		{name: "unknown", expected: 0},
+		{name: "external name", externalName: "42", status: ptr.To(int64(7)), expected: 42},
+		{name: "status", status: ptr.To(int64(7)), expected: 7},
+		{name: "external name of another kind", externalName: "octocat", status: ptr.To(int64(7)), expected: 7},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := &orgMembershipv1alpha1.OrgMembership{}
+			cr.Status.InvitationId = tc.status
+			if len(tc.externalName) > 0 {
+				meta.SetExternalName(cr, tc.externalName)
+			}
+
+			if got := invitationID(cr); got != tc.expected {
+				t.Fatalf("expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+// newServer returns a server for a user invited to the acme organization
+// as a member, with the id 7, and the calls it received.
+func newServer(t *testing.T) (*httptest.Server, *[]string) {
+	calls := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/orgs/acme/memberships/octocat":
+			fmt.Fprint(w, `{"role": "member", "state": "pending"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/orgs/acme/invitations":
+			fmt.Fprint(w, `[{"id": 7, "login": "octocat", "role": "direct_member"}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/users/octocat":
+			fmt.Fprint(w, `{"id": 1, "login": "octocat"}`)
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 8, "login": "octocat", "role": "admin"}`)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &calls
+}
+
+func newExternal(srv *httptest.Server) *external {
+	return &external{
+		log:   logging.NewNopLogger(),
+		ghCli: github.NewClient(github.ClientOpts{ApiURL: srv.URL + "/", Token: "token", HttpClient: srv.Client()}),
+		rec:   record.NewFakeRecorder(10),
+	}
+}
+
+func TestObservePending(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     *string
+		upToDate bool
+	}{
+		{name: "default role", upToDate: true},
+		{name: "member", role: ptr.To(orgMembershipv1alpha1.RoleMember), upToDate: true},
+		{name: "admin", role: ptr.To(orgMembershipv1alpha1.RoleAdmin), upToDate: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, _ := newServer(t)
+
+			cr := &orgMembershipv1alpha1.OrgMembership{Spec: orgMembershipv1alpha1.OrgMembershipSpec{Org: "acme", Username: ptr.To("octocat"), Role: tc.role}}
+
+			obs, err := newExternal(srv).Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !obs.ResourceExists || obs.ResourceUpToDate != tc.upToDate {
+				t.Fatalf("unexpected observation: %+v", obs)
+			}
+			if ptr.Deref(cr.Status.Role, "") != orgMembershipv1alpha1.RoleMember {
+				t.Fatalf("expected the member role, got %v", cr.Status.Role)
+			}
+		})
+	}
+}
+
+func TestUpdatePending(t *testing.T) {
+	srv, calls := newServer(t)
+
+	cr := &orgMembershipv1alpha1.OrgMembership{Spec: orgMembershipv1alpha1.OrgMembershipSpec{Org: "acme", Username: ptr.To("octocat"), Role: ptr.To(orgMembershipv1alpha1.RoleAdmin)}}
+	cr.Status.State = ptr.To(orgMembershipv1alpha1.StatePending)
+	meta.SetExternalName(cr, "7")
+
+	if err := newExternal(srv).Update(context.Background(), cr); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"DELETE /orgs/acme/invitations/7", "GET /users/octocat", "POST /orgs/acme/invitations"}
+	if !slices.Equal(*calls, expected) {
+		t.Fatalf("expected %q, got %q", expected, *calls)
+	}
+	if id := invitationID(cr); id != 8 {
+		t.Fatalf("expected the new invitation, got %d", id)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     orgMembershipv1alpha1.OrgMembershipSpec
+		expected []string
+	}{
+		{
+			name:     "pending invitation cancelled",
+			spec:     orgMembershipv1alpha1.OrgMembershipSpec{Org: "acme", Username: ptr.To("octocat")},
+			expected: []string{"GET /orgs/acme/invitations", "DELETE /orgs/acme/invitations/7"},
+		},
+		{
+			name:     "active member kept",
+			spec:     orgMembershipv1alpha1.OrgMembershipSpec{Org: "acme", Username: ptr.To("hubot")},
+			expected: []string{"GET /orgs/acme/invitations"},
+		},
+		{
+			name:     "member removed",
+			spec:     orgMembershipv1alpha1.OrgMembershipSpec{Org: "acme", Username: ptr.To("hubot"), DeletionMode: ptr.To(orgMembershipv1alpha1.DeletionModeRemove)},
+			expected: []string{"DELETE /orgs/acme/memberships/hubot"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, calls := newServer(t)
+
+			cr := &orgMembershipv1alpha1.OrgMembership{Spec: tc.spec}
+
+			if err := newExternal(srv).Delete(context.Background(), cr); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(*calls, tc.expected) {
+				t.Fatalf("expected %q, got %q", tc.expected, *calls)
+			}
+		})
+	}
+}
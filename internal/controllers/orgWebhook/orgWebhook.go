@@ -0,0 +1,322 @@
+package orgWebhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/controller"
+	"github.com/krateoplatformops/provider-runtime/pkg/ratelimiter"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/event"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/reconciler"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+
+	webhookv1alpha1 "github.com/krateoplatformops/github-provider/apis/webhook/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients"
+	"github.com/krateoplatformops/github-provider/internal/clients/fingerprint"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+	"github.com/krateoplatformops/github-provider/internal/controllers/webhook"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const (
+	errNotOrgWebhook = "managed resource is not an orgWebhook custom resource"
+)
+
+// Setup adds a controller that reconciles Token managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := reconciler.ControllerName(webhookv1alpha1.OrgWebhookGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+
+	recorder := mgr.GetEventRecorderFor(name)
+
+	r := reconciler.NewReconciler(mgr,
+		resource.ManagedKind(webhookv1alpha1.OrgWebhookGroupVersionKind),
+		reconciler.WithExternalConnecter(&connector{
+			kube:     mgr.GetClient(),
+			log:      log,
+			recorder: recorder,
+		}),
+		reconciler.WithPollInterval(o.PollInterval),
+		reconciler.WithLogger(log),
+		reconciler.WithRecorder(event.NewAPIRecorder(recorder)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&webhookv1alpha1.OrgWebhook{}).
+		Complete(ratelimiter.New(name, r, o.GlobalRateLimiter))
+}
+
+type connector struct {
+	kube     client.Client
+	log      logging.Logger
+	recorder record.EventRecorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (reconciler.ExternalClient, error) {
+	cr, ok := mg.(*webhookv1alpha1.OrgWebhook)
+	if !ok {
+		return nil, errors.New(errNotOrgWebhook)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	csr := spec.Credentials.SecretRef
+	if csr == nil {
+		return nil, fmt.Errorf("no credentials secret referenced")
+	}
+
+	token, err := resource.GetSecret(ctx, c.kube, csr.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := github.ClientOpts{
+		ApiURL: spec.ApiUrl,
+		Token:  token,
+	}
+	opts.HttpClient = clients.DefaultHttpClient()
+
+	if ptr.Deref(cr.Spec.Verbose, false) {
+		opts.HttpClient = &http.Client{
+			Transport: &clients.VerboseTracer{RoundTripper: http.DefaultTransport},
+			Timeout:   50 * time.Second,
+		}
+	}
+
+	key, err := fingerprint.Key(ctx, c.kube, cr.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{
+		kube:  c.kube,
+		log:   c.log,
+		ghCli: github.NewClient(opts),
+		rec:   c.recorder,
+		key:   key,
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube  client.Client
+	log   logging.Logger
+	ghCli *github.Client
+	rec   record.EventRecorder
+	// key signs the fingerprint of the webhook secret.
+	key string
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	return nil // NOOP
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (reconciler.ExternalObservation, error) {
+	cr, ok := mg.(*webhookv1alpha1.OrgWebhook)
+	if !ok {
+		return reconciler.ExternalObservation{}, errors.New(errNotOrgWebhook)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	hook, err := e.get(cr)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	if hook == nil {
+		e.log.Debug("Webhook not found", "org", spec.Org, "url", spec.Url)
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	cr.Status.Id = ptr.To(hook.ID)
+
+	lateInitialized := false
+	if id := strconv.FormatInt(hook.ID, 10); meta.GetExternalName(cr) != id {
+		meta.SetExternalName(cr, id)
+		lateInitialized = true
+	}
+
+	secret, err := webhook.Secret(ctx, e.kube, &spec.WebhookSpec)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	cr.SetConditions(prv1.Available())
+
+	switch lr := hook.LastResponse; {
+	case lr.Failed():
+		e.log.Debug("Webhook delivery failed", "org", spec.Org, "id", hook.ID, "code", *lr.Code, "message", lr.Message)
+		cr.SetConditions(webhookv1alpha1.DeliveryFailed(*lr.Code, lr.Message))
+	case lr.Delivered():
+		cr.SetConditions(webhookv1alpha1.DeliverySucceeded())
+	default:
+		cr.SetConditions(webhookv1alpha1.NoDelivery())
+	}
+
+	// GitHub never returns the webhook secret: it is written again when
+	// the source value changes.
+	diff := hook.Diff(&spec.WebhookSpec)
+	write, changed := webhook.ObserveSecret(cr, e.key, secret, hook)
+	if write {
+		diff = append(diff, "secret: changed")
+	}
+	lateInitialized = lateInitialized || changed
+	if len(diff) > 0 {
+		e.log.Debug("Webhook is not up to date", "org", spec.Org, "id", hook.ID, "diff", diff)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "NotUpToDate", "Webhook '%s' of org '%s' differs from desired state: %s", spec.Url, spec.Org, strings.Join(diff, ", "))
+
+		return reconciler.ExternalObservation{
+			ResourceExists:          true,
+			ResourceUpToDate:        false,
+			ResourceLateInitialized: lateInitialized,
+			Diff:                    strings.Join(diff, "\n"),
+		}, nil
+	}
+
+	e.log.Debug("Webhook already exists", "org", spec.Org, "id", hook.ID)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "AlreadyExists", "Webhook '%s' of org '%s' already exists", spec.Url, spec.Org)
+
+	return reconciler.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        true,
+		ResourceLateInitialized: lateInitialized,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*webhookv1alpha1.OrgWebhook)
+	if !ok {
+		return errors.New(errNotOrgWebhook)
+	}
+
+	cr.SetConditions(prv1.Creating())
+
+	spec := cr.Spec.DeepCopy()
+
+	secret, err := webhook.Secret(ctx, e.kube, &spec.WebhookSpec)
+	if err != nil {
+		return err
+	}
+
+	hook, err := e.ghCli.OrgWebhook().Create(spec, secret)
+	if err != nil {
+		return err
+	}
+
+	webhook.RecordSecret(cr, e.key, secret, hook)
+	meta.SetExternalName(cr, strconv.FormatInt(hook.ID, 10))
+
+	e.log.Debug("Webhook created", "org", spec.Org, "id", hook.ID)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "WebhookCreated", "Webhook '%s' of org '%s' created", spec.Url, spec.Org)
+
+	// The outcome of the ping is reported by the Delivered condition once
+	// GitHub has delivered it, a failure to send it does not fail the creation.
+	if err := e.ghCli.OrgWebhook().Ping(spec, hook.ID); err != nil {
+		e.log.Debug("Webhook ping failed", "org", spec.Org, "id", hook.ID, "error", err)
+		e.rec.Eventf(cr, corev1.EventTypeWarning, "PingFailed", "Webhook '%s' of org '%s' could not be pinged: %s", spec.Url, spec.Org, err.Error())
+	} else {
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "WebhookPinged", "Webhook '%s' of org '%s' pinged", spec.Url, spec.Org)
+	}
+
+	return nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*webhookv1alpha1.OrgWebhook)
+	if !ok {
+		return errors.New(errNotOrgWebhook)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	id := webhookID(cr)
+	if id == 0 {
+		return fmt.Errorf("webhook '%s' of org '%s' has no id", spec.Url, spec.Org)
+	}
+
+	secret, err := webhook.Secret(ctx, e.kube, &spec.WebhookSpec)
+	if err != nil {
+		return err
+	}
+
+	err = e.ghCli.OrgWebhook().Update(spec, id, secret)
+	if err != nil {
+		return err
+	}
+
+	e.log.Debug("Webhook updated", "org", spec.Org, "id", id)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "WebhookUpdated", "Webhook '%s' of org '%s' updated", spec.Url, spec.Org)
+
+	return nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*webhookv1alpha1.OrgWebhook)
+	if !ok {
+		return errors.New(errNotOrgWebhook)
+	}
+
+	cr.SetConditions(prv1.Deleting())
+
+	spec := cr.Spec.DeepCopy()
+
+	id := webhookID(cr)
+	if id == 0 {
+		return nil
+	}
+
+	err := e.ghCli.OrgWebhook().Delete(spec, id)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Webhook deleted", "org", spec.Org, "id", id)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "WebhookDeleted", "Webhook '%s' of org '%s' deleted", spec.Url, spec.Org)
+
+	return nil
+}
+
+// get fetches the webhook by its id, or looks it up by URL while the id
+// is not known yet.
+func (e *external) get(cr *webhookv1alpha1.OrgWebhook) (*github.Webhook, error) {
+	spec := cr.Spec.DeepCopy()
+
+	if id := webhookID(cr); id != 0 {
+		return e.ghCli.OrgWebhook().Get(spec, id)
+	}
+
+	return e.ghCli.OrgWebhook().Find(spec)
+}
+
+// webhookID returns the id of the webhook, stored in the status and in the
+// external name, or 0 if it is not known yet.
+func webhookID(cr *webhookv1alpha1.OrgWebhook) int64 {
+	if id := ptr.Deref(cr.Status.Id, 0); id != 0 {
+		return id
+	}
+	if id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64); err == nil {
+		return id
+	}
+	return 0
+}
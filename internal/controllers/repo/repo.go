@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 
 	"github.com/krateoplatformops/provider-runtime/pkg/controller"
@@ -18,6 +21,7 @@ import (
 
 	"github.com/krateoplatformops/provider-runtime/pkg/event"
 	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
 	"github.com/krateoplatformops/provider-runtime/pkg/reconciler"
 	"github.com/krateoplatformops/provider-runtime/pkg/resource"
 
@@ -29,6 +33,9 @@ import (
 
 const (
 	errNotRepo = "managed resource is not a repo custom resource"
+
+	// forkPollInterval is how often a pending fork is checked.
+	forkPollInterval = 10 * time.Second
 )
 
 // Setup adds a controller that reconciles Token managed resources.
@@ -47,6 +54,7 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 			recorder: recorder,
 		}),
 		reconciler.WithPollInterval(o.PollInterval),
+		reconciler.WithPollIntervalHook(pollInterval),
 		reconciler.WithLogger(log),
 		reconciler.WithRecorder(event.NewAPIRecorder(recorder)))
 
@@ -57,6 +65,14 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		Complete(ratelimiter.New(name, r, o.GlobalRateLimiter))
 }
 
+// pollInterval shortens the poll interval while a fork is being created.
+func pollInterval(mg resource.Managed, d time.Duration) time.Duration {
+	if mg.GetCondition(prv1.TypeReady).Reason == prv1.ReasonCreating && d > forkPollInterval {
+		return forkPollInterval
+	}
+	return d
+}
+
 type connector struct {
 	kube     client.Client
 	log      logging.Logger
@@ -123,27 +139,123 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (reconciler
 
 	spec := cr.Spec.DeepCopy()
 
-	ok, err := e.ghCli.Repos().Exists(spec)
+	repo, err := e.get(cr)
 	if err != nil {
 		return reconciler.ExternalObservation{}, err
 	}
 
-	if ok {
-		e.log.Debug("Repo already exists", "org", spec.Org, "name", spec.Name)
-		e.rec.Eventf(cr, corev1.EventTypeNormal, "AlredyExists", "Repo '%s/%s' already exists", spec.Org, spec.Name)
+	if repo == nil {
+		e.log.Debug("Repo does not exists", "org", spec.Org, "name", spec.Name)
 
-		cr.SetConditions(prv1.Available())
 		return reconciler.ExternalObservation{
-			ResourceExists:   true,
+			ResourceExists:   false,
 			ResourceUpToDate: true,
 		}, nil
 	}
 
-	e.log.Debug("Repo does not exists", "org", spec.Org, "name", spec.Name)
+	// Track the repository by its id from now on, so that changes to the
+	// name or to the owner can be applied in place.
+	lateInitialized := false
+	if id := strconv.FormatInt(repo.ID, 10); meta.GetExternalName(cr) != id {
+		meta.SetExternalName(cr, id)
+		lateInitialized = true
+	}
+
+	setStatus(cr, repo)
+
+	if meta.WasDeleted(cr) && archiveOnDelete(spec) && repo.Archived {
+		// The repository has been archived in place of being deleted:
+		// report it as gone so that the finalizer can be removed.
+		e.log.Debug("Repo archived on deletion", "org", spec.Org, "name", spec.Name)
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	if spec.Fork != nil {
+		// A fork is reported as soon as it is requested, but its content
+		// is copied in the background: wait for the default branch.
+		ready, err := e.ghCli.Repos().BranchExists(located(spec, repo), repo.DefaultBranch)
+		if err != nil {
+			return reconciler.ExternalObservation{}, err
+		}
+		if !ready {
+			e.log.Debug("Fork not ready yet", "org", spec.Org, "name", spec.Name)
+			e.rec.Eventf(cr, corev1.EventTypeNormal, "ForkPending", "Fork '%s/%s' of '%s/%s' is not ready yet", spec.Org, spec.Name, spec.Fork.Owner, spec.Fork.Repo)
+
+			cr.SetConditions(prv1.Creating())
+			return reconciler.ExternalObservation{
+				ResourceExists:   true,
+				ResourceUpToDate: true,
+			}, nil
+		}
+	}
+
+	topics, err := e.ghCli.Repos().GetTopics(located(spec, repo))
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+	cr.Status.Topics = topics
+
+	cr.SetConditions(prv1.Available())
+
+	if !strings.EqualFold(repo.Owner.Login, spec.Org) {
+		if strings.EqualFold(ptr.Deref(cr.Status.PendingTransfer, ""), spec.Org) {
+			e.log.Debug("Repo transfer pending", "org", spec.Org, "name", spec.Name, "owner", repo.Owner.Login)
+			e.rec.Eventf(cr, corev1.EventTypeNormal, "TransferPending", "Repo '%s' transfer to '%s' is pending", repo.FullName, spec.Org)
+
+			cr.SetConditions(repov1alpha1.TransferPending(spec.Org))
+			return reconciler.ExternalObservation{
+				ResourceExists:          true,
+				ResourceUpToDate:        true,
+				ResourceLateInitialized: lateInitialized,
+			}, nil
+		}
+	} else if cr.Status.PendingTransfer != nil {
+		e.log.Debug("Repo transfer complete", "org", spec.Org, "name", spec.Name)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "TransferComplete", "Repo '%s' transferred to '%s'", repo.FullName, spec.Org)
+
+		cr.Status.PendingTransfer = nil
+		cr.SetConditions(repov1alpha1.TransferComplete())
+	}
+
+	empty, err := e.skipDefaultBranch(cr, located(spec, repo), repo.DefaultBranch)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+	if empty {
+		spec.DefaultBranch = nil
+	}
+
+	diff := diffRepo(spec, repo, topics)
+	if !readOnly(spec, repo) {
+		more, err := e.diffDependabot(located(spec, repo), spec.SecurityAndAnalysis)
+		if err != nil {
+			return reconciler.ExternalObservation{}, err
+		}
+		diff = append(diff, more...)
+	}
+	if len(diff) > 0 {
+		e.log.Debug("Repo is not up to date", "org", spec.Org, "name", spec.Name, "diff", diff)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "NotUpToDate", "Repo '%s/%s' differs from desired state: %s", spec.Org, spec.Name, strings.Join(diff, ", "))
+
+		return reconciler.ExternalObservation{
+			ResourceExists:          true,
+			ResourceUpToDate:        false,
+			ResourceLateInitialized: lateInitialized,
+			Diff:                    strings.Join(diff, "\n"),
+		}, nil
+	}
+
+	e.log.Debug("Repo already exists", "org", spec.Org, "name", spec.Name)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "AlredyExists", "Repo '%s/%s' already exists", spec.Org, spec.Name)
 
 	return reconciler.ExternalObservation{
-		ResourceExists:   false,
-		ResourceUpToDate: true,
+		ResourceExists:          true,
+		ResourceUpToDate:        true,
+		ResourceLateInitialized: lateInitialized,
 	}, nil
 }
 
@@ -157,10 +269,24 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) error {
 
 	spec := cr.Spec.DeepCopy()
 
-	err := e.ghCli.Repos().Create(spec)
+	repo, err := e.ghCli.Repos().Create(spec)
 	if err != nil {
 		return err
 	}
+	meta.SetExternalName(cr, strconv.FormatInt(repo.ID, 10))
+
+	if src := spec.Fork; src != nil {
+		e.log.Debug("Repo fork requested", "org", spec.Org, "name", spec.Name, "upstream", src.Owner+"/"+src.Repo)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "RepoForked", "Repo '%s/%s' fork of '%s/%s' requested", spec.Org, spec.Name, src.Owner, src.Repo)
+		return nil
+	}
+
+	if tpl := spec.TemplateRef; tpl != nil {
+		e.log.Debug("Repo created from template", "org", spec.Org, "name", spec.Name, "template", tpl.Owner+"/"+tpl.Name)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "RepoCreated", "Repo '%s/%s' created from template '%s/%s'", spec.Org, spec.Name, tpl.Owner, tpl.Name)
+		return nil
+	}
+
 	e.log.Debug("Repo created", "org", spec.Org, "name", spec.Name)
 	e.rec.Eventf(cr, corev1.EventTypeNormal, "RepoCreated", "Repo '%s/%s' created", spec.Org, spec.Name)
 
@@ -168,7 +294,109 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) error {
 }
 
 func (e *external) Update(ctx context.Context, mg resource.Managed) error {
-	return nil // noop
+	cr, ok := mg.(*repov1alpha1.Repo)
+	if !ok {
+		return errors.New(errNotRepo)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	repo, err := e.get(cr)
+	if err != nil {
+		return err
+	}
+	if repo == nil {
+		return fmt.Errorf("repo '%s/%s' not found", spec.Org, spec.Name)
+	}
+	current := located(spec, repo)
+
+	// GitHub rejects any change to an archived repository, be it a
+	// rename, a transfer or its settings, until it is unarchived.
+	if readOnly(spec, repo) {
+		e.log.Debug("Repo is archived, not updating", "org", spec.Org, "name", spec.Name)
+		e.rec.Eventf(cr, corev1.EventTypeWarning, "RepoArchived", "Repo '%s' is archived: set spec.archived to false to change it", repo.FullName)
+
+		return nil
+	}
+
+	if !strings.EqualFold(current.Org, spec.Org) {
+		// The settings are applied once the transfer has completed.
+		err := e.ghCli.Repos().Transfer(current, spec.Org, spec.Name)
+		if err != nil {
+			return err
+		}
+		cr.Status.PendingTransfer = ptr.To(spec.Org)
+		cr.SetConditions(repov1alpha1.TransferPending(spec.Org))
+
+		e.log.Debug("Repo transfer requested", "from", repo.FullName, "org", spec.Org, "name", spec.Name)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "TransferRequested", "Repo '%s' transfer to '%s/%s' requested", repo.FullName, spec.Org, spec.Name)
+
+		return nil
+	}
+
+	if current.Name != spec.Name {
+		err := e.ghCli.Repos().Rename(current, spec.Name)
+		if err != nil {
+			return err
+		}
+		e.log.Debug("Repo renamed", "from", repo.FullName, "org", spec.Org, "name", spec.Name)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "RepoRenamed", "Repo '%s' renamed to '%s/%s'", repo.FullName, spec.Org, spec.Name)
+	}
+
+	// An archived repository is read-only: unarchive it before
+	// applying the settings and archive it only afterwards.
+	if spec.Archived != nil && !*spec.Archived {
+		if err := e.ghCli.Repos().SetArchived(spec, false); err != nil {
+			return err
+		}
+	}
+
+	empty, err := e.skipDefaultBranch(cr, spec, repo.DefaultBranch)
+	if err != nil {
+		return err
+	}
+	if empty {
+		spec.DefaultBranch = nil
+	}
+
+	if err := e.switchDefaultBranch(cr, spec, repo.DefaultBranch); err != nil {
+		return err
+	}
+
+	err = e.ghCli.Repos().Update(spec)
+	if err != nil {
+		return err
+	}
+
+	if spec.Topics != nil {
+		if err := e.ghCli.Repos().ReplaceTopics(spec, spec.Topics); err != nil {
+			return err
+		}
+	}
+
+	// Dependabot security updates depend on the alerts: set them last.
+	if sa := spec.SecurityAndAnalysis; sa != nil {
+		if sa.VulnerabilityAlerts != nil {
+			if err := e.ghCli.Repos().SetVulnerabilityAlerts(spec, *sa.VulnerabilityAlerts); err != nil {
+				return err
+			}
+		}
+		if sa.AutomatedSecurityFixes != nil {
+			if err := e.ghCli.Repos().SetAutomatedSecurityFixes(spec, *sa.AutomatedSecurityFixes); err != nil {
+				return err
+			}
+		}
+	}
+
+	if ptr.Deref(spec.Archived, false) {
+		if err := e.ghCli.Repos().SetArchived(spec, true); err != nil {
+			return err
+		}
+	}
+	e.log.Debug("Repo updated", "org", spec.Org, "name", spec.Name)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "RepoUpdated", "Repo '%s/%s' updated", spec.Org, spec.Name)
+
+	return nil
 }
 
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -181,7 +409,27 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 
 	spec := cr.Spec.DeepCopy()
 
-	err := e.ghCli.Repos().Delete(spec)
+	repo, err := e.get(cr)
+	if err != nil {
+		return err
+	}
+	if repo == nil {
+		return nil
+	}
+	spec = located(spec, repo)
+
+	if archiveOnDelete(spec) {
+		err := e.ghCli.Repos().SetArchived(spec, true)
+		if err != nil {
+			return err
+		}
+		e.log.Debug("Repo archived", "org", spec.Org, "name", spec.Name)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "RepoArchived", "Repo '%s/%s' archived", spec.Org, spec.Name)
+
+		return nil
+	}
+
+	err = e.ghCli.Repos().Delete(spec)
 	if err != nil {
 		return err
 	}
@@ -190,3 +438,208 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 
 	return nil
 }
+
+// get fetches the repository by its id when it is known, by its owner and
+// name otherwise.
+func (e *external) get(cr *repov1alpha1.Repo) (*github.Repository, error) {
+	if id := repoID(cr); id > 0 {
+		return e.ghCli.Repos().GetByID(id)
+	}
+	return e.ghCli.Repos().Get(cr.Spec.DeepCopy())
+}
+
+// repoID returns the repository id recorded in the external name or,
+// failing that, in the status. It returns 0 if the id is not known yet.
+func repoID(cr *repov1alpha1.Repo) int64 {
+	if id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64); err == nil {
+		return id
+	}
+	return ptr.Deref(cr.Status.Id, 0)
+}
+
+// located returns a copy of the spec that points to the current owner and
+// name of the repository, which differ from the desired ones while a
+// rename or a transfer is pending.
+func located(spec *repov1alpha1.RepoSpec, repo *github.Repository) *repov1alpha1.RepoSpec {
+	res := spec.DeepCopy()
+	res.Org = repo.Owner.Login
+	res.Name = repo.Name
+	return res
+}
+
+// skipDefaultBranch reports whether the desired default branch cannot be
+// set yet because nothing has been pushed to the repository: an empty
+// repository has no branch to rename nor to switch to.
+func (e *external) skipDefaultBranch(cr *repov1alpha1.Repo, spec *repov1alpha1.RepoSpec, current string) (bool, error) {
+	if spec.DefaultBranch == nil || *spec.DefaultBranch == current {
+		return false, nil
+	}
+
+	ok, err := e.ghCli.Repos().HasBranches(spec)
+	if err != nil || ok {
+		return false, err
+	}
+	e.log.Debug("Default branch switch skipped on empty repo", "org", spec.Org, "name", spec.Name, "defaultBranch", *spec.DefaultBranch)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "BranchSwitchSkipped", "Repo '%s/%s' is empty: default branch '%s' will be set after the first push", spec.Org, spec.Name, *spec.DefaultBranch)
+
+	return true, nil
+}
+
+// switchDefaultBranch renames the current default branch when the desired
+// default branch does not exist yet. When it does exist, the default
+// branch is switched along with the other settings.
+func (e *external) switchDefaultBranch(cr *repov1alpha1.Repo, spec *repov1alpha1.RepoSpec, current string) error {
+	if spec.DefaultBranch == nil || *spec.DefaultBranch == current {
+		return nil
+	}
+
+	ok, err := e.ghCli.Repos().BranchExists(spec, *spec.DefaultBranch)
+	if err != nil || ok {
+		return err
+	}
+
+	err = e.ghCli.Repos().RenameBranch(spec, current, *spec.DefaultBranch)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Default branch renamed", "org", spec.Org, "name", spec.Name, "from", current, "to", *spec.DefaultBranch)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "BranchRenamed", "Repo '%s/%s' default branch '%s' renamed to '%s'", spec.Org, spec.Name, current, *spec.DefaultBranch)
+
+	return nil
+}
+
+// diffDependabot compares the desired Dependabot settings, which are
+// not part of the repository object, with the observed ones.
+func (e *external) diffDependabot(spec *repov1alpha1.RepoSpec, sa *repov1alpha1.SecurityAndAnalysis) ([]string, error) {
+	diff := []string{}
+	if sa == nil {
+		return diff, nil
+	}
+
+	if sa.VulnerabilityAlerts != nil {
+		enabled, err := e.ghCli.Repos().VulnerabilityAlertsEnabled(spec)
+		if err != nil {
+			return nil, err
+		}
+		diff = github.DiffDeclared(diff, "securityAndAnalysis.vulnerabilityAlerts", sa.VulnerabilityAlerts, enabled)
+	}
+
+	if sa.AutomatedSecurityFixes != nil {
+		enabled, err := e.ghCli.Repos().AutomatedSecurityFixesEnabled(spec)
+		if err != nil {
+			return nil, err
+		}
+		diff = github.DiffDeclared(diff, "securityAndAnalysis.automatedSecurityFixes", sa.AutomatedSecurityFixes, enabled)
+	}
+
+	return diff, nil
+}
+
+// readOnly reports whether the repository is archived and meant to stay
+// so, in which case none of its settings can be changed.
+func readOnly(spec *repov1alpha1.RepoSpec, repo *github.Repository) bool {
+	return repo.Archived && ptr.Deref(spec.Archived, true)
+}
+
+// archiveOnDelete reports whether the repository must be archived
+// instead of deleted when the resource is deleted.
+func archiveOnDelete(spec *repov1alpha1.RepoSpec) bool {
+	return ptr.Deref(spec.DeletionMode, repov1alpha1.DeletionModeDelete) == repov1alpha1.DeletionModeArchive
+}
+
+// setStatus copies the observed repository metadata into the status.
+func setStatus(cr *repov1alpha1.Repo, repo *github.Repository) {
+	cr.Status.Url = ptr.To(repo.HtmlURL)
+	cr.Status.Private = ptr.To(repo.Private)
+	cr.Status.Id = ptr.To(repo.ID)
+	cr.Status.NodeId = ptr.To(repo.NodeID)
+	cr.Status.CloneUrl = ptr.To(repo.CloneURL)
+	cr.Status.SshUrl = ptr.To(repo.SshURL)
+	cr.Status.DefaultBranch = ptr.To(repo.DefaultBranch)
+	cr.Status.Visibility = ptr.To(repo.Visibility)
+	cr.Status.Archived = ptr.To(repo.Archived)
+	cr.Status.Size = ptr.To(repo.Size)
+
+	cr.Status.CreatedAt = nil
+	if repo.CreatedAt != nil {
+		cr.Status.CreatedAt = &metav1.Time{Time: *repo.CreatedAt}
+	}
+	cr.Status.Template = nil
+	if repo.TemplateRepository != nil {
+		cr.Status.Template = ptr.To(repo.TemplateRepository.FullName)
+	}
+
+	cr.Status.Parent = nil
+	if repo.Parent != nil {
+		cr.Status.Parent = ptr.To(repo.Parent.FullName)
+	}
+	cr.Status.Source = nil
+	if repo.Source != nil {
+		cr.Status.Source = ptr.To(repo.Source.FullName)
+	}
+
+	cr.Status.PushedAt = nil
+	if repo.PushedAt != nil {
+		cr.Status.PushedAt = &metav1.Time{Time: *repo.PushedAt}
+	}
+}
+
+// diffRepo compares the desired state with the observed repository and
+// returns a description of each field that differs. Optional fields that
+// are not declared in the spec are ignored.
+func diffRepo(spec *repov1alpha1.RepoSpec, repo *github.Repository, topics []string) []string {
+	diff := []string{}
+
+	if !strings.EqualFold(spec.Org, repo.Owner.Login) {
+		diff = append(diff, fmt.Sprintf("org: %s (observed: %s)", spec.Org, repo.Owner.Login))
+	}
+	if spec.Name != repo.Name {
+		diff = append(diff, fmt.Sprintf("name: %s (observed: %s)", spec.Name, repo.Name))
+	}
+
+	diff = github.DiffDeclared(diff, "archived", spec.Archived, repo.Archived)
+	if readOnly(spec, repo) {
+		return diff
+	}
+
+	if spec.Visibility != nil {
+		diff = github.DiffDeclared(diff, "visibility", spec.Visibility, repo.Visibility)
+	} else if spec.Private != repo.Private {
+		diff = append(diff, fmt.Sprintf("private: %v (observed: %v)", spec.Private, repo.Private))
+	}
+
+	diff = github.DiffDeclared(diff, "description", spec.Description, repo.Description)
+	diff = github.DiffDeclared(diff, "homepage", spec.Homepage, repo.Homepage)
+	diff = github.DiffDeclared(diff, "defaultBranch", spec.DefaultBranch, repo.DefaultBranch)
+	diff = github.DiffDeclared(diff, "hasIssues", spec.HasIssues, repo.HasIssues)
+	diff = github.DiffDeclared(diff, "hasProjects", spec.HasProjects, repo.HasProjects)
+	diff = github.DiffDeclared(diff, "hasWiki", spec.HasWiki, repo.HasWiki)
+	diff = github.DiffDeclared(diff, "hasDiscussions", spec.HasDiscussions, repo.HasDiscussions)
+	diff = github.DiffDeclared(diff, "allowSquashMerge", spec.AllowSquashMerge, repo.AllowSquashMerge)
+	diff = github.DiffDeclared(diff, "allowMergeCommit", spec.AllowMergeCommit, repo.AllowMergeCommit)
+	diff = github.DiffDeclared(diff, "allowRebaseMerge", spec.AllowRebaseMerge, repo.AllowRebaseMerge)
+	diff = github.DiffDeclared(diff, "allowAutoMerge", spec.AllowAutoMerge, repo.AllowAutoMerge)
+	diff = github.DiffDeclared(diff, "deleteBranchOnMerge", spec.DeleteBranchOnMerge, repo.DeleteBranchOnMerge)
+	diff = github.DiffDeclared(diff, "squashMergeCommitTitle", spec.SquashMergeCommitTitle, repo.SquashMergeCommitTitle)
+	diff = github.DiffDeclared(diff, "squashMergeCommitMessage", spec.SquashMergeCommitMessage, repo.SquashMergeCommitMessage)
+	diff = github.DiffDeclared(diff, "mergeCommitTitle", spec.MergeCommitTitle, repo.MergeCommitTitle)
+	diff = github.DiffDeclared(diff, "mergeCommitMessage", spec.MergeCommitMessage, repo.MergeCommitMessage)
+	diff = github.DiffDeclared(diff, "isTemplate", spec.IsTemplate, repo.IsTemplate)
+
+	if spec.Topics != nil && !github.SameItems(spec.Topics, topics) {
+		diff = append(diff, fmt.Sprintf("topics: %v (observed: %v)", spec.Topics, topics))
+	}
+
+	if sa := spec.SecurityAndAnalysis; sa != nil {
+		observed := repo.SecurityAndAnalysis
+		if observed == nil {
+			observed = &github.SecurityAndAnalysis{}
+		}
+		diff = github.DiffDeclared(diff, "securityAndAnalysis.advancedSecurity", sa.AdvancedSecurity, observed.AdvancedSecurity.Enabled())
+		diff = github.DiffDeclared(diff, "securityAndAnalysis.secretScanning", sa.SecretScanning, observed.SecretScanning.Enabled())
+		diff = github.DiffDeclared(diff, "securityAndAnalysis.secretScanningPushProtection", sa.SecretScanningPushProtection, observed.SecretScanningPushProtection.Enabled())
+		diff = github.DiffDeclared(diff, "securityAndAnalysis.secretScanningValidityChecks", sa.SecretScanningValidityChecks, observed.SecretScanningValidityChecks.Enabled())
+	}
+
+	return diff
+}
@@ -0,0 +1,311 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	repov1alpha1 "github.com/krateoplatformops/github-provider/apis/repo/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+)
+
+func TestDiffRepo(t *testing.T) {
+	observed := func() *github.Repository {
+		return &github.Repository{
+			Name:          "web",
+			Owner:         github.RepositoryOwner{Login: "Acme"},
+			Private:       true,
+			Visibility:    "private",
+			Description:   "The website",
+			DefaultBranch: "main",
+			HasIssues:     true,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		spec     repov1alpha1.RepoSpec
+		repo     *github.Repository
+		topics   []string
+		expected []string
+	}{
+		{
+			name:     "undeclared settings are ignored",
+			spec:     repov1alpha1.RepoSpec{Org: "acme", Name: "web", Private: true},
+			repo:     observed(),
+			expected: []string{},
+		},
+		{
+			name:     "renamed and transferred",
+			spec:     repov1alpha1.RepoSpec{Org: "umbrella", Name: "site", Private: true},
+			repo:     observed(),
+			expected: []string{"org: umbrella (observed: Acme)", "name: site (observed: web)"},
+		},
+		{
+			name:     "private",
+			spec:     repov1alpha1.RepoSpec{Org: "acme", Name: "web", Private: false},
+			repo:     observed(),
+			expected: []string{"private: false (observed: true)"},
+		},
+		{
+			name:     "visibility takes precedence over private",
+			spec:     repov1alpha1.RepoSpec{Org: "acme", Name: "web", Visibility: ptr.To("internal")},
+			repo:     observed(),
+			expected: []string{"visibility: internal (observed: private)"},
+		},
+		{
+			name: "declared settings",
+			spec: repov1alpha1.RepoSpec{
+				Org: "acme", Name: "web", Private: true,
+				Description:   ptr.To("The website"),
+				DefaultBranch: ptr.To("trunk"),
+				HasIssues:     ptr.To(false),
+			},
+			repo:     observed(),
+			expected: []string{"defaultBranch: trunk (observed: main)", "hasIssues: false (observed: true)"},
+		},
+		{
+			name:     "topics in another order",
+			spec:     repov1alpha1.RepoSpec{Org: "acme", Name: "web", Private: true, Topics: []string{"go", "web"}},
+			repo:     observed(),
+			topics:   []string{"web", "go"},
+			expected: []string{},
+		},
+		{
+			name:     "topics removed",
+			spec:     repov1alpha1.RepoSpec{Org: "acme", Name: "web", Private: true, Topics: []string{}},
+			repo:     observed(),
+			topics:   []string{"go"},
+			expected: []string{"topics: [] (observed: [go])"},
+		},
+		{
+			name: "security features",
+			spec: repov1alpha1.RepoSpec{Org: "acme", Name: "web", Private: true, SecurityAndAnalysis: &repov1alpha1.SecurityAndAnalysis{
+				SecretScanning:               ptr.To(true),
+				SecretScanningPushProtection: ptr.To(false),
+			}},
+			repo:     observed(),
+			expected: []string{"securityAndAnalysis.secretScanning: true (observed: false)"},
+		},
+		{
+			name:     "archived repository settings are ignored",
+			spec:     repov1alpha1.RepoSpec{Org: "acme", Name: "web", Private: false, HasIssues: ptr.To(false)},
+			repo:     &github.Repository{Name: "web", Owner: github.RepositoryOwner{Login: "acme"}, Private: true, HasIssues: true, Archived: true},
+			expected: []string{},
+		},
+		{
+			name:     "unarchived repository",
+			spec:     repov1alpha1.RepoSpec{Org: "acme", Name: "web", Private: true, Archived: ptr.To(false)},
+			repo:     &github.Repository{Name: "web", Owner: github.RepositoryOwner{Login: "acme"}, Private: true, Archived: true},
+			expected: []string{"archived: false (observed: true)"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := diffRepo(&tc.spec, tc.repo, tc.topics)
+			if !slices.Equal(diff, tc.expected) {
+				t.Fatalf("expected %q, got %q", tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestSetStatus(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	cr := &repov1alpha1.Repo{}
+	cr.Status.PushedAt = &metav1.Time{Time: created}
+
+	setStatus(cr, &github.Repository{
+		ID:            42,
+		HtmlURL:       "https://github.com/acme/web",
+		Private:       true,
+		Visibility:    "private",
+		DefaultBranch: "main",
+		CreatedAt:     &created,
+	})
+
+	if ptr.Deref(cr.Status.Id, 0) != 42 || ptr.Deref(cr.Status.Url, "") != "https://github.com/acme/web" {
+		t.Fatalf("unexpected id and url: %v, %v", cr.Status.Id, cr.Status.Url)
+	}
+	if ptr.Deref(cr.Status.Visibility, "") != "private" || ptr.Deref(cr.Status.DefaultBranch, "") != "main" {
+		t.Fatalf("unexpected visibility and default branch: %v, %v", cr.Status.Visibility, cr.Status.DefaultBranch)
+	}
+	if cr.Status.CreatedAt == nil || !cr.Status.CreatedAt.Time.Equal(created) {
+		t.Fatalf("unexpected creation time: %v", cr.Status.CreatedAt)
+	}
+	if cr.Status.PushedAt != nil {
+		t.Fatalf("expected the push time to be cleared, got %v", cr.Status.PushedAt)
+	}
+}
+
+func TestDiffDependabot(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/web/vulnerability-alerts":
+			w.WriteHeader(http.StatusNoContent)
+		case "/repos/acme/web/automated-security-fixes":
+			fmt.Fprint(w, `{"enabled": false}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	e := &external{ghCli: github.NewClient(github.ClientOpts{ApiURL: srv.URL + "/", Token: "token", HttpClient: srv.Client()})}
+	spec := &repov1alpha1.RepoSpec{Org: "acme", Name: "web"}
+
+	tests := []struct {
+		name     string
+		sa       *repov1alpha1.SecurityAndAnalysis
+		expected []string
+	}{
+		{name: "not declared", sa: nil, expected: []string{}},
+		{name: "up to date", sa: &repov1alpha1.SecurityAndAnalysis{VulnerabilityAlerts: ptr.To(true), AutomatedSecurityFixes: ptr.To(false)}, expected: []string{}},
+		{
+			name:     "different",
+			sa:       &repov1alpha1.SecurityAndAnalysis{VulnerabilityAlerts: ptr.To(false), AutomatedSecurityFixes: ptr.To(true)},
+			expected: []string{"securityAndAnalysis.vulnerabilityAlerts: false (observed: true)", "securityAndAnalysis.automatedSecurityFixes: true (observed: false)"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff, err := e.diffDependabot(spec, tc.sa)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(diff, tc.expected) {
+				t.Fatalf("expected %q, got %q", tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestRepoID(t *testing.T) {
+	tests := []struct {
+		name         string
+		externalName string
+		status       *int64
+		expected     int64
+	}{
+		{name: "unknown", expected: 0},
+		{name: "external name", externalName: "42", status: ptr.To(int64(7)), expected: 42},
+		{name: "status", externalName: "web", status: ptr.To(int64(7)), expected: 7},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := &repov1alpha1.Repo{}
+			cr.Status.Id = tc.status
+			if len(tc.externalName) > 0 {
+				meta.SetExternalName(cr, tc.externalName)
+			}
+
+			if got := repoID(cr); got != tc.expected {
+				t.Fatalf("expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     repov1alpha1.RepoSpec
+		observed string
+		expected []string
+	}{
+		{
+			name:     "settings",
+			spec:     repov1alpha1.RepoSpec{Org: "acme", Name: "web", Description: ptr.To("The website")},
+			observed: `{"id": 1, "name": "web", "owner": {"login": "acme"}, "default_branch": "main"}`,
+			expected: []string{"GET /repos/acme/web", "PATCH /repos/acme/web"},
+		},
+		{
+			name:     "renamed with topics",
+			spec:     repov1alpha1.RepoSpec{Org: "acme", Name: "site", Topics: []string{"go"}},
+			observed: `{"id": 1, "name": "web", "owner": {"login": "acme"}, "default_branch": "main"}`,
+			expected: []string{"GET /repos/acme/site", "PATCH /repos/acme/web", "PATCH /repos/acme/site", "PUT /repos/acme/site/topics"},
+		},
+		{
+			name:     "transferred",
+			spec:     repov1alpha1.RepoSpec{Org: "umbrella", Name: "web"},
+			observed: `{"id": 1, "name": "web", "owner": {"login": "acme"}, "default_branch": "main"}`,
+			expected: []string{"GET /repos/umbrella/web", "POST /repos/acme/web/transfer"},
+		},
+		{
+			name:     "default branch of an empty repository",
+			spec:     repov1alpha1.RepoSpec{Org: "acme", Name: "web", DefaultBranch: ptr.To("trunk")},
+			observed: `{"id": 1, "name": "web", "owner": {"login": "acme"}, "default_branch": "main"}`,
+			expected: []string{"GET /repos/acme/web", "GET /repos/acme/web/branches", "PATCH /repos/acme/web"},
+		},
+		{
+			name:     "archived repository is left alone",
+			spec:     repov1alpha1.RepoSpec{Org: "acme", Name: "site", Topics: []string{"go"}, SecurityAndAnalysis: &repov1alpha1.SecurityAndAnalysis{VulnerabilityAlerts: ptr.To(true)}},
+			observed: `{"id": 1, "name": "web", "owner": {"login": "acme"}, "default_branch": "main", "archived": true}`,
+			expected: []string{"GET /repos/acme/site"},
+		},
+		{
+			name:     "unarchived first",
+			spec:     repov1alpha1.RepoSpec{Org: "acme", Name: "web", Archived: ptr.To(false), SecurityAndAnalysis: &repov1alpha1.SecurityAndAnalysis{VulnerabilityAlerts: ptr.To(true)}},
+			observed: `{"id": 1, "name": "web", "owner": {"login": "acme"}, "default_branch": "main", "archived": true}`,
+			expected: []string{"GET /repos/acme/web", "PATCH /repos/acme/web", "PATCH /repos/acme/web", "PUT /repos/acme/web/vulnerability-alerts"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := []string{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+				switch r.Method {
+				case http.MethodGet:
+					if strings.HasSuffix(r.URL.Path, "/branches") {
+						fmt.Fprint(w, `[]`)
+						return
+					}
+					fmt.Fprint(w, tc.observed)
+				case http.MethodPost:
+					w.WriteHeader(http.StatusAccepted)
+					fmt.Fprint(w, `{}`)
+				case http.MethodPatch:
+					fmt.Fprint(w, `{}`)
+				default:
+					if r.URL.Path == "/repos/acme/site/topics" {
+						fmt.Fprint(w, `{"names": ["go"]}`)
+						return
+					}
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			defer srv.Close()
+
+			e := &external{
+				log:   logging.NewNopLogger(),
+				ghCli: github.NewClient(github.ClientOpts{ApiURL: srv.URL + "/", Token: "token", HttpClient: srv.Client()}),
+				rec:   record.NewFakeRecorder(10),
+			}
+			cr := &repov1alpha1.Repo{Spec: tc.spec}
+
+			if err := e.Update(context.Background(), cr); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(calls, tc.expected) {
+				t.Fatalf("expected %q, got %q", tc.expected, calls)
+			}
+		})
+	}
+}
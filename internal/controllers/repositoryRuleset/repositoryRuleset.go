@@ -0,0 +1,273 @@
+package repositoryRuleset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/controller"
+	"github.com/krateoplatformops/provider-runtime/pkg/ratelimiter"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/event"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/reconciler"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+
+	rulesetv1alpha1 "github.com/krateoplatformops/github-provider/apis/ruleset/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const (
+	errNotRepositoryRuleset = "managed resource is not a repositoryRuleset custom resource"
+)
+
+// Setup adds a controller that reconciles Token managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := reconciler.ControllerName(rulesetv1alpha1.RepositoryRulesetGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+
+	recorder := mgr.GetEventRecorderFor(name)
+
+	r := reconciler.NewReconciler(mgr,
+		resource.ManagedKind(rulesetv1alpha1.RepositoryRulesetGroupVersionKind),
+		reconciler.WithExternalConnecter(&connector{
+			kube:     mgr.GetClient(),
+			log:      log,
+			recorder: recorder,
+		}),
+		reconciler.WithPollInterval(o.PollInterval),
+		reconciler.WithLogger(log),
+		reconciler.WithRecorder(event.NewAPIRecorder(recorder)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&rulesetv1alpha1.RepositoryRuleset{}).
+		Complete(ratelimiter.New(name, r, o.GlobalRateLimiter))
+}
+
+type connector struct {
+	kube     client.Client
+	log      logging.Logger
+	recorder record.EventRecorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (reconciler.ExternalClient, error) {
+	cr, ok := mg.(*rulesetv1alpha1.RepositoryRuleset)
+	if !ok {
+		return nil, errors.New(errNotRepositoryRuleset)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	csr := spec.Credentials.SecretRef
+	if csr == nil {
+		return nil, fmt.Errorf("no credentials secret referenced")
+	}
+
+	token, err := resource.GetSecret(ctx, c.kube, csr.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := github.ClientOpts{
+		ApiURL: spec.ApiUrl,
+		Token:  token,
+	}
+	opts.HttpClient = clients.DefaultHttpClient()
+
+	if ptr.Deref(cr.Spec.Verbose, false) {
+		opts.HttpClient = &http.Client{
+			Transport: &clients.VerboseTracer{RoundTripper: http.DefaultTransport},
+			Timeout:   50 * time.Second,
+		}
+	}
+
+	return &external{
+		kube:  c.kube,
+		log:   c.log,
+		ghCli: github.NewClient(opts),
+		rec:   c.recorder,
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube  client.Client
+	log   logging.Logger
+	ghCli *github.Client
+	rec   record.EventRecorder
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	return nil // NOOP
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (reconciler.ExternalObservation, error) {
+	cr, ok := mg.(*rulesetv1alpha1.RepositoryRuleset)
+	if !ok {
+		return reconciler.ExternalObservation{}, errors.New(errNotRepositoryRuleset)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	rs, err := e.get(cr)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	if rs == nil {
+		e.log.Debug("Ruleset not found", "owner", spec.Owner, "repo", spec.Repo, "name", spec.Name)
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	cr.Status.Id = ptr.To(rs.ID)
+
+	lateInitialized := false
+	if id := strconv.FormatInt(rs.ID, 10); meta.GetExternalName(cr) != id {
+		meta.SetExternalName(cr, id)
+		lateInitialized = true
+	}
+
+	cr.SetConditions(prv1.Available())
+
+	diff := rs.Diff(&spec.RulesetSpec)
+	if spec.Conditions != nil {
+		diff = append(diff, rs.DiffRefName(spec.Conditions.RefName)...)
+	}
+	if len(diff) > 0 {
+		e.log.Debug("Ruleset is not up to date", "owner", spec.Owner, "repo", spec.Repo, "id", rs.ID, "diff", diff)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "NotUpToDate", "Ruleset '%s' of repo '%s/%s' differs from desired state: %s", spec.Name, spec.Owner, spec.Repo, strings.Join(diff, ", "))
+
+		return reconciler.ExternalObservation{
+			ResourceExists:          true,
+			ResourceUpToDate:        false,
+			ResourceLateInitialized: lateInitialized,
+			Diff:                    strings.Join(diff, "\n"),
+		}, nil
+	}
+
+	e.log.Debug("Ruleset already exists", "owner", spec.Owner, "repo", spec.Repo, "id", rs.ID)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "AlreadyExists", "Ruleset '%s' of repo '%s/%s' already exists", spec.Name, spec.Owner, spec.Repo)
+
+	return reconciler.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        true,
+		ResourceLateInitialized: lateInitialized,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*rulesetv1alpha1.RepositoryRuleset)
+	if !ok {
+		return errors.New(errNotRepositoryRuleset)
+	}
+
+	cr.SetConditions(prv1.Creating())
+
+	spec := cr.Spec.DeepCopy()
+
+	rs, err := e.ghCli.RepositoryRuleset().Create(spec)
+	if err != nil {
+		return err
+	}
+
+	cr.Status.Id = ptr.To(rs.ID)
+	meta.SetExternalName(cr, strconv.FormatInt(rs.ID, 10))
+
+	e.log.Debug("Ruleset created", "owner", spec.Owner, "repo", spec.Repo, "id", rs.ID)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "RulesetCreated", "Ruleset '%s' of repo '%s/%s' created", spec.Name, spec.Owner, spec.Repo)
+
+	return nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*rulesetv1alpha1.RepositoryRuleset)
+	if !ok {
+		return errors.New(errNotRepositoryRuleset)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	id := rulesetID(cr)
+	if id == 0 {
+		return fmt.Errorf("ruleset '%s' of repo '%s/%s' has no id", spec.Name, spec.Owner, spec.Repo)
+	}
+
+	err := e.ghCli.RepositoryRuleset().Update(spec, id)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Ruleset updated", "owner", spec.Owner, "repo", spec.Repo, "id", id)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "RulesetUpdated", "Ruleset '%s' of repo '%s/%s' updated", spec.Name, spec.Owner, spec.Repo)
+
+	return nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*rulesetv1alpha1.RepositoryRuleset)
+	if !ok {
+		return errors.New(errNotRepositoryRuleset)
+	}
+
+	cr.SetConditions(prv1.Deleting())
+
+	spec := cr.Spec.DeepCopy()
+
+	id := rulesetID(cr)
+	if id == 0 {
+		return nil
+	}
+
+	err := e.ghCli.RepositoryRuleset().Delete(spec, id)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Ruleset deleted", "owner", spec.Owner, "repo", spec.Repo, "id", id)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "RulesetDeleted", "Ruleset '%s' of repo '%s/%s' deleted", spec.Name, spec.Owner, spec.Repo)
+
+	return nil
+}
+
+// get fetches the ruleset by its id, or looks it up by name while the id
+// is not known yet.
+func (e *external) get(cr *rulesetv1alpha1.RepositoryRuleset) (*github.Ruleset, error) {
+	spec := cr.Spec.DeepCopy()
+
+	if id := rulesetID(cr); id != 0 {
+		return e.ghCli.RepositoryRuleset().Get(spec, id)
+	}
+
+	return e.ghCli.RepositoryRuleset().Find(spec)
+}
+
+// rulesetID returns the id of the ruleset, stored in the status and in the
+// external name, or 0 if it is not known yet.
+func rulesetID(cr *rulesetv1alpha1.RepositoryRuleset) int64 {
+	if id := ptr.Deref(cr.Status.Id, 0); id != 0 {
+		return id
+	}
+	if id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64); err == nil {
+		return id
+	}
+	return 0
+}
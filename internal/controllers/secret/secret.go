@@ -0,0 +1,381 @@
+// Package secret holds the controller shared by the secret managed
+// resources: each kind of secret only provides the glue to its managed
+// resource and to its GitHub secret store.
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/controller"
+	"github.com/krateoplatformops/provider-runtime/pkg/ratelimiter"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/event"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/reconciler"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+
+	"github.com/krateoplatformops/github-provider/apis/common"
+	secretv1alpha1 "github.com/krateoplatformops/github-provider/apis/secret/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients"
+	"github.com/krateoplatformops/github-provider/internal/clients/fingerprint"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+// Managed is a managed resource holding a secret.
+type Managed[S any] interface {
+	*S
+	resource.Managed
+	GetSecretSpec() *secretv1alpha1.SecretSpec
+	GetSecretStatus() *secretv1alpha1.SecretStatus
+}
+
+// Connection is how a managed resource reaches the GitHub API.
+type Connection struct {
+	ApiUrl      string
+	Credentials *prv1.CredentialSelectors
+	Verbose     *bool
+}
+
+// Store is the glue between the shared controller and a kind of secret.
+type Store[T resource.Managed] struct {
+	GroupKind        string
+	GroupVersionKind schema.GroupVersionKind
+
+	// Service returns the GitHub secret store of the secret.
+	Service func(*github.Client) *github.SecretService
+	// Connection returns how the managed resource reaches the GitHub API.
+	Connection func(cr T) Connection
+	// Scope returns the owner of the secret.
+	Scope func(cr T) github.Scope
+}
+
+// Setup adds a controller that reconciles the managed resources of a kind
+// of secret.
+func Setup[S any, T Managed[S]](mgr ctrl.Manager, o controller.Options, store Store[T]) error {
+	name := reconciler.ControllerName(store.GroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+
+	recorder := mgr.GetEventRecorderFor(name)
+
+	r := reconciler.NewReconciler(mgr,
+		resource.ManagedKind(store.GroupVersionKind),
+		reconciler.WithExternalConnecter(&connector[S, T]{
+			kube:     mgr.GetClient(),
+			log:      log,
+			recorder: recorder,
+			store:    store,
+		}),
+		reconciler.WithPollInterval(o.PollInterval),
+		reconciler.WithLogger(log),
+		reconciler.WithRecorder(event.NewAPIRecorder(recorder)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(T(new(S))).
+		Complete(ratelimiter.New(name, r, o.GlobalRateLimiter))
+}
+
+type connector[S any, T Managed[S]] struct {
+	kube     client.Client
+	log      logging.Logger
+	recorder record.EventRecorder
+	store    Store[T]
+}
+
+func (c *connector[S, T]) Connect(ctx context.Context, mg resource.Managed) (reconciler.ExternalClient, error) {
+	cr, ok := mg.(T)
+	if !ok {
+		return nil, errors.New(errNotSecret(c.store))
+	}
+
+	conn := c.store.Connection(cr)
+
+	csr := conn.Credentials.SecretRef
+	if csr == nil {
+		return nil, fmt.Errorf("no credentials secret referenced")
+	}
+
+	token, err := resource.GetSecret(ctx, c.kube, csr.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := github.ClientOpts{
+		ApiURL: conn.ApiUrl,
+		Token:  token,
+	}
+	opts.HttpClient = clients.DefaultHttpClient()
+
+	if ptr.Deref(conn.Verbose, false) {
+		opts.HttpClient = &http.Client{
+			Transport: &clients.VerboseTracer{RoundTripper: http.DefaultTransport},
+			Timeout:   50 * time.Second,
+		}
+	}
+
+	key, err := fingerprint.Key(ctx, c.kube, cr.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	ghCli := github.NewClient(opts)
+
+	return &external[S, T]{
+		kube:    c.kube,
+		log:     c.log,
+		ghCli:   ghCli,
+		secrets: c.store.Service(ghCli),
+		rec:     c.recorder,
+		store:   c.store,
+		key:     key,
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external[S any, T Managed[S]] struct {
+	kube    client.Client
+	log     logging.Logger
+	ghCli   *github.Client
+	secrets *github.SecretService
+	rec     record.EventRecorder
+	store   Store[T]
+	// key signs the fingerprint of the secret value.
+	key string
+}
+
+func (c *external[S, T]) Disconnect(_ context.Context) error {
+	return nil // NOOP
+}
+
+func (e *external[S, T]) Observe(ctx context.Context, mg resource.Managed) (reconciler.ExternalObservation, error) {
+	cr, ok := mg.(T)
+	if !ok {
+		return reconciler.ExternalObservation{}, errors.New(errNotSecret(e.store))
+	}
+
+	spec := cr.GetSecretSpec().DeepCopy()
+
+	scope, err := e.scope(cr)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	sec, err := e.secrets.Get(scope, spec.Name)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	if sec == nil {
+		e.log.Debug("Secret not found", "scope", scope.String(), "name", spec.Name)
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	status := cr.GetSecretStatus()
+	status.CreatedAt = &metav1.Time{Time: sec.CreatedAt}
+	status.UpdatedAt = &metav1.Time{Time: sec.UpdatedAt}
+
+	cr.SetConditions(prv1.Available())
+
+	value, err := resource.GetSecret(ctx, e.kube, spec.ValueRef.DeepCopy())
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	diff, err := e.diffSettings(spec, scope, sec)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	// GitHub never returns secret values: the secret is written again when
+	// the source value changes, or when it was updated by someone else.
+	// The value is also written along with any other setting.
+	sum := fingerprint.Sum(e.key, cr.GetUID(), value)
+	write, changed := fingerprint.Observe(cr, sum, sec.UpdatedAt, true)
+	if write {
+		diff = append(diff, "value: changed")
+	} else if len(diff) > 0 {
+		changed = fingerprint.Stage(cr, sum, sec.UpdatedAt) || changed
+	}
+	if len(diff) > 0 {
+		e.log.Debug("Secret is not up to date", "scope", scope.String(), "name", spec.Name, "diff", diff)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "NotUpToDate", "Secret '%s' of %s differs from desired state: %s", spec.Name, scope, strings.Join(diff, ", "))
+
+		return reconciler.ExternalObservation{
+			ResourceExists:          true,
+			ResourceUpToDate:        false,
+			ResourceLateInitialized: changed,
+			Diff:                    strings.Join(diff, "\n"),
+		}, nil
+	}
+
+	e.log.Debug("Secret already exists", "scope", scope.String(), "name", spec.Name)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "AlreadyExists", "Secret '%s' of %s already exists", spec.Name, scope)
+
+	return reconciler.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        true,
+		ResourceLateInitialized: changed,
+	}, nil
+}
+
+func (e *external[S, T]) Create(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(T)
+	if !ok {
+		return errors.New(errNotSecret(e.store))
+	}
+
+	cr.SetConditions(prv1.Creating())
+
+	spec := cr.GetSecretSpec().DeepCopy()
+
+	scope, err := e.scope(cr)
+	if err != nil {
+		return err
+	}
+
+	value, err := resource.GetSecret(ctx, e.kube, spec.ValueRef.DeepCopy())
+	if err != nil {
+		return err
+	}
+
+	err = e.put(spec, scope, value)
+	if err != nil {
+		return err
+	}
+
+	sec, err := e.secrets.Get(scope, spec.Name)
+	if err != nil {
+		return err
+	}
+	if sec == nil {
+		return fmt.Errorf("secret '%s' of %s not found after write", spec.Name, scope)
+	}
+	fingerprint.Record(cr, fingerprint.Sum(e.key, cr.GetUID(), value), sec.UpdatedAt)
+
+	e.log.Debug("Secret created", "scope", scope.String(), "name", spec.Name)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "SecretCreated", "Secret '%s' of %s created", spec.Name, scope)
+
+	return nil
+}
+
+func (e *external[S, T]) Update(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(T)
+	if !ok {
+		return errors.New(errNotSecret(e.store))
+	}
+
+	spec := cr.GetSecretSpec().DeepCopy()
+
+	scope, err := e.scope(cr)
+	if err != nil {
+		return err
+	}
+
+	value, err := resource.GetSecret(ctx, e.kube, spec.ValueRef.DeepCopy())
+	if err != nil {
+		return err
+	}
+
+	// The fingerprint of the value has been staged by Observe, and is
+	// confirmed once GitHub reports the secret updated.
+	err = e.put(spec, scope, value)
+	if err != nil {
+		return err
+	}
+
+	e.log.Debug("Secret updated", "scope", scope.String(), "name", spec.Name)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "SecretUpdated", "Secret '%s' of %s updated", spec.Name, scope)
+
+	return nil
+}
+
+func (e *external[S, T]) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(T)
+	if !ok {
+		return errors.New(errNotSecret(e.store))
+	}
+
+	cr.SetConditions(prv1.Deleting())
+
+	spec := cr.GetSecretSpec().DeepCopy()
+
+	scope, err := e.scope(cr)
+	if err != nil {
+		return err
+	}
+
+	err = e.secrets.Delete(scope, spec.Name)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Secret deleted", "scope", scope.String(), "name", spec.Name)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "SecretDeleted", "Secret '%s' of %s deleted", spec.Name, scope)
+
+	return nil
+}
+
+// diffSettings compares the visibility and the selected repositories of
+// an organization secret with the desired ones. The secrets of repositories
+// and environments have no other setting than their value.
+func (e *external[S, T]) diffSettings(spec *secretv1alpha1.SecretSpec, scope github.Scope, sec *github.Secret) ([]string, error) {
+	diff := []string{}
+	if !scope.IsOrg() {
+		return diff, nil
+	}
+
+	visibility := ptr.Deref(spec.Visibility, common.VisibilityPrivate)
+	diff = github.DiffValue(diff, "visibility", visibility, sec.Visibility)
+	if visibility == common.VisibilitySelected && sec.Visibility == visibility {
+		repos, err := e.secrets.SelectedRepositories(scope, spec.Name)
+		if err != nil {
+			return nil, err
+		}
+		diff = github.DiffItems(diff, "selectedRepositories", spec.SelectedRepositories, repos)
+	}
+	return diff, nil
+}
+
+// put encrypts and writes the value along with the other settings.
+func (e *external[S, T]) put(spec *secretv1alpha1.SecretSpec, scope github.Scope, value string) error {
+	var repositoryIDs []int64
+	if scope.IsOrg() && ptr.Deref(spec.Visibility, "") == common.VisibilitySelected {
+		ids, err := e.ghCli.Repos().IDs(scope.Org, spec.SelectedRepositories)
+		if err != nil {
+			return err
+		}
+		repositoryIDs = ids
+	}
+
+	return e.secrets.Put(scope, spec, value, repositoryIDs)
+}
+
+// scope returns the validated owner of the secret.
+func (e *external[S, T]) scope(cr T) (github.Scope, error) {
+	scope := e.store.Scope(cr)
+	return scope, scope.Validate()
+}
+
+func errNotSecret[T resource.Managed](store Store[T]) string {
+	return fmt.Sprintf("managed resource is not a %s custom resource", store.GroupKind)
+}
@@ -0,0 +1,97 @@
+package secret
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+
+	"github.com/krateoplatformops/github-provider/apis/common"
+	secretv1alpha1 "github.com/krateoplatformops/github-provider/apis/secret/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+)
+
+func TestDiffSettings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/acme/actions/secrets/TOKEN/repositories" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"total_count": 2, "repositories": [{"name": "web"}, {"name": "api"}]}`)
+	}))
+	defer srv.Close()
+
+	ghCli := github.NewClient(github.ClientOpts{ApiURL: srv.URL + "/", Token: "token", HttpClient: srv.Client()})
+	e := &external[secretv1alpha1.ActionsSecret, *secretv1alpha1.ActionsSecret]{
+		ghCli:   ghCli,
+		secrets: ghCli.ActionsSecrets(),
+	}
+
+	org := github.Scope{Org: "acme"}
+	repo := github.Scope{Owner: "acme", Repo: "web"}
+
+	tests := []struct {
+		name     string
+		spec     secretv1alpha1.SecretSpec
+		scope    github.Scope
+		observed string
+		expected []string
+	}{
+		{
+			name:     "repository secret",
+			spec:     secretv1alpha1.SecretSpec{Name: "TOKEN", Visibility: ptr.To(common.VisibilityAll)},
+			scope:    repo,
+			expected: []string{},
+		},
+		{
+			name:     "private by default",
+			spec:     secretv1alpha1.SecretSpec{Name: "TOKEN"},
+			scope:    org,
+			observed: common.VisibilityPrivate,
+			expected: []string{},
+		},
+		{
+			name:     "different visibility",
+			spec:     secretv1alpha1.SecretSpec{Name: "TOKEN", Visibility: ptr.To(common.VisibilityAll)},
+			scope:    org,
+			observed: common.VisibilityPrivate,
+			expected: []string{"visibility: all (observed: private)"},
+		},
+		{
+			name:     "selected repositories in another order",
+			spec:     secretv1alpha1.SecretSpec{Name: "TOKEN", Visibility: ptr.To(common.VisibilitySelected), SelectedRepositories: []string{"api", "web"}},
+			scope:    org,
+			observed: common.VisibilitySelected,
+			expected: []string{},
+		},
+		{
+			name:     "missing selected repository",
+			spec:     secretv1alpha1.SecretSpec{Name: "TOKEN", Visibility: ptr.To(common.VisibilitySelected), SelectedRepositories: []string{"api", "docs", "web"}},
+			scope:    org,
+			observed: common.VisibilitySelected,
+			expected: []string{"selectedRepositories: [api docs web] (observed: [web api])"},
+		},
+		{
+			name:     "repositories not compared before the visibility is selected",
+			spec:     secretv1alpha1.SecretSpec{Name: "TOKEN", Visibility: ptr.To(common.VisibilitySelected), SelectedRepositories: []string{"docs"}},
+			scope:    org,
+			observed: common.VisibilityAll,
+			expected: []string{"visibility: selected (observed: all)"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff, err := e.diffSettings(&tc.spec, tc.scope, &github.Secret{Name: tc.spec.Name, Visibility: tc.observed})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(diff, tc.expected) {
+				t.Fatalf("expected %q, got %q", tc.expected, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/controller"
+	"github.com/krateoplatformops/provider-runtime/pkg/ratelimiter"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/event"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/reconciler"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+
+	teamv1alpha1 "github.com/krateoplatformops/github-provider/apis/team/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const (
+	errNotTeam = "managed resource is not a team custom resource"
+)
+
+// Setup adds a controller that reconciles Token managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := reconciler.ControllerName(teamv1alpha1.TeamGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+
+	recorder := mgr.GetEventRecorderFor(name)
+
+	r := reconciler.NewReconciler(mgr,
+		resource.ManagedKind(teamv1alpha1.TeamGroupVersionKind),
+		reconciler.WithExternalConnecter(&connector{
+			kube:     mgr.GetClient(),
+			log:      log,
+			recorder: recorder,
+		}),
+		reconciler.WithPollInterval(o.PollInterval),
+		reconciler.WithLogger(log),
+		reconciler.WithRecorder(event.NewAPIRecorder(recorder)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&teamv1alpha1.Team{}).
+		Complete(ratelimiter.New(name, r, o.GlobalRateLimiter))
+}
+
+type connector struct {
+	kube     client.Client
+	log      logging.Logger
+	recorder record.EventRecorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (reconciler.ExternalClient, error) {
+	cr, ok := mg.(*teamv1alpha1.Team)
+	if !ok {
+		return nil, errors.New(errNotTeam)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	csr := spec.Credentials.SecretRef
+	if csr == nil {
+		return nil, fmt.Errorf("no credentials secret referenced")
+	}
+
+	token, err := resource.GetSecret(ctx, c.kube, csr.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := github.ClientOpts{
+		ApiURL: spec.ApiUrl,
+		Token:  token,
+	}
+	opts.HttpClient = clients.DefaultHttpClient()
+
+	if ptr.Deref(cr.Spec.Verbose, false) {
+		opts.HttpClient = &http.Client{
+			Transport: &clients.VerboseTracer{RoundTripper: http.DefaultTransport},
+			Timeout:   50 * time.Second,
+		}
+	}
+
+	return &external{
+		kube:  c.kube,
+		log:   c.log,
+		ghCli: github.NewClient(opts),
+		rec:   c.recorder,
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube  client.Client
+	log   logging.Logger
+	ghCli *github.Client
+	rec   record.EventRecorder
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	return nil // NOOP
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (reconciler.ExternalObservation, error) {
+	cr, ok := mg.(*teamv1alpha1.Team)
+	if !ok {
+		return reconciler.ExternalObservation{}, errors.New(errNotTeam)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	team, err := e.get(cr)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	if team == nil {
+		e.log.Debug("Team not found", "org", spec.Org, "name", spec.Name)
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	// Track the team by its id from now on, so that it can be renamed.
+	lateInitialized := false
+	if id := strconv.FormatInt(team.ID, 10); meta.GetExternalName(cr) != id {
+		meta.SetExternalName(cr, id)
+		lateInitialized = true
+	}
+
+	cr.Status.Id = ptr.To(team.ID)
+	cr.Status.Slug = ptr.To(team.Slug)
+	cr.Status.Url = ptr.To(team.HTMLURL)
+
+	cr.SetConditions(prv1.Available())
+
+	diff := team.Diff(spec)
+	if len(diff) > 0 {
+		e.log.Debug("Team is not up to date", "org", spec.Org, "slug", team.Slug, "diff", diff)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "NotUpToDate", "Team '%s/%s' differs from desired state: %s", spec.Org, team.Slug, strings.Join(diff, ", "))
+
+		return reconciler.ExternalObservation{
+			ResourceExists:          true,
+			ResourceUpToDate:        false,
+			ResourceLateInitialized: lateInitialized,
+			Diff:                    strings.Join(diff, "\n"),
+		}, nil
+	}
+
+	e.log.Debug("Team already exists", "org", spec.Org, "slug", team.Slug)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "AlreadyExists", "Team '%s/%s' already exists", spec.Org, team.Slug)
+
+	return reconciler.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        true,
+		ResourceLateInitialized: lateInitialized,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*teamv1alpha1.Team)
+	if !ok {
+		return errors.New(errNotTeam)
+	}
+
+	cr.SetConditions(prv1.Creating())
+
+	spec := cr.Spec.DeepCopy()
+
+	parentID, err := e.parentID(spec)
+	if err != nil {
+		return err
+	}
+
+	team, err := e.ghCli.Teams().Create(spec, parentID)
+	if err != nil {
+		return err
+	}
+
+	meta.SetExternalName(cr, strconv.FormatInt(team.ID, 10))
+
+	e.log.Debug("Team created", "org", spec.Org, "slug", team.Slug, "id", team.ID)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "TeamCreated", "Team '%s/%s' created", spec.Org, team.Slug)
+
+	return nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*teamv1alpha1.Team)
+	if !ok {
+		return errors.New(errNotTeam)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	team, err := e.get(cr)
+	if err != nil {
+		return err
+	}
+	if team == nil {
+		return fmt.Errorf("team '%s' of org '%s' not found", spec.Name, spec.Org)
+	}
+
+	parentID, err := e.parentID(spec)
+	if err != nil {
+		return err
+	}
+
+	team, err = e.ghCli.Teams().Update(spec, team.Slug, parentID)
+	if err != nil {
+		return err
+	}
+
+	e.log.Debug("Team updated", "org", spec.Org, "slug", team.Slug, "id", team.ID)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "TeamUpdated", "Team '%s/%s' updated", spec.Org, team.Slug)
+
+	return nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*teamv1alpha1.Team)
+	if !ok {
+		return errors.New(errNotTeam)
+	}
+
+	cr.SetConditions(prv1.Deleting())
+
+	spec := cr.Spec.DeepCopy()
+
+	team, err := e.get(cr)
+	if err != nil {
+		return err
+	}
+	if team == nil {
+		return nil
+	}
+
+	err = e.ghCli.Teams().Delete(spec.Org, team.Slug)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Team deleted", "org", spec.Org, "slug", team.Slug, "id", team.ID)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "TeamDeleted", "Team '%s/%s' deleted", spec.Org, team.Slug)
+
+	return nil
+}
+
+// get fetches the team by its id when it is known, by the slug generated
+// from its name otherwise.
+func (e *external) get(cr *teamv1alpha1.Team) (*github.Team, error) {
+	if id := teamID(cr); id > 0 {
+		return e.ghCli.Teams().GetByID(cr.Spec.Org, id)
+	}
+	return e.ghCli.Teams().GetBySlug(cr.Spec.Org, slugify(cr.Spec.Name))
+}
+
+// parentID resolves the slug of the parent team to its id. It returns 0
+// for top level teams.
+func (e *external) parentID(spec *teamv1alpha1.TeamSpec) (int64, error) {
+	slug := ptr.Deref(spec.ParentTeam, "")
+	if len(slug) == 0 {
+		return 0, nil
+	}
+
+	parent, err := e.ghCli.Teams().GetBySlug(spec.Org, slug)
+	if err != nil {
+		return 0, err
+	}
+	if parent == nil {
+		return 0, fmt.Errorf("parent team '%s/%s' not found", spec.Org, slug)
+	}
+	return parent.ID, nil
+}
+
+// teamID returns the team id recorded in the external name or, failing
+// that, in the status. It returns 0 if the id is not known yet.
+func teamID(cr *teamv1alpha1.Team) int64 {
+	if id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64); err == nil {
+		return id
+	}
+	return ptr.Deref(cr.Status.Id, 0)
+}
+
+// slugify returns the slug GitHub generates from a team name: lower case,
+// with every run of other characters than letters and digits replaced by
+// a dash.
+func slugify(name string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') || r == '_' {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return sb.String()
+}
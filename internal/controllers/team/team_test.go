@@ -0,0 +1,22 @@
+package team
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "platform", expected: "platform"},
+		{name: "Platform Team", expected: "platform-team"},
+		{name: "  Platform -- Team!  ", expected: "platform-team"},
+		{name: "team_42", expected: "team_42"},
+		{name: "!!!", expected: ""},
+	}
+
+	for _, tc := range tests {
+		if got := slugify(tc.name); got != tc.expected {
+			t.Errorf("slugify(%q): expected %q, got %q", tc.name, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package teamMembers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/controller"
+	"github.com/krateoplatformops/provider-runtime/pkg/event"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+	"github.com/krateoplatformops/provider-runtime/pkg/ratelimiter"
+	"github.com/krateoplatformops/provider-runtime/pkg/reconciler"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+
+	teamv1alpha1 "github.com/krateoplatformops/github-provider/apis/team/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+)
+
+const (
+	errNotTeamMembers = "managed resource is not a teamMembers custom resource"
+)
+
+// Setup adds a controller that reconciles Token managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := reconciler.ControllerName(teamv1alpha1.TeamMembersGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+
+	recorder := mgr.GetEventRecorderFor(name)
+
+	r := reconciler.NewReconciler(mgr,
+		resource.ManagedKind(teamv1alpha1.TeamMembersGroupVersionKind),
+		reconciler.WithExternalConnecter(&connector{
+			kube:     mgr.GetClient(),
+			log:      log,
+			recorder: recorder,
+		}),
+		reconciler.WithPollInterval(o.PollInterval),
+		reconciler.WithLogger(log),
+		reconciler.WithRecorder(event.NewAPIRecorder(recorder)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&teamv1alpha1.TeamMembers{}).
+		Complete(ratelimiter.New(name, r, o.GlobalRateLimiter))
+}
+
+type connector struct {
+	kube     client.Client
+	log      logging.Logger
+	recorder record.EventRecorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (reconciler.ExternalClient, error) {
+	cr, ok := mg.(*teamv1alpha1.TeamMembers)
+	if !ok {
+		return nil, errors.New(errNotTeamMembers)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	csr := spec.Credentials.SecretRef
+	if csr == nil {
+		return nil, fmt.Errorf("no credentials secret referenced")
+	}
+
+	token, err := resource.GetSecret(ctx, c.kube, csr.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := github.ClientOpts{
+		ApiURL: spec.ApiUrl,
+		Token:  token,
+	}
+	opts.HttpClient = clients.DefaultHttpClient()
+
+	if ptr.Deref(cr.Spec.Verbose, false) {
+		opts.HttpClient = &http.Client{
+			Transport: &clients.VerboseTracer{RoundTripper: http.DefaultTransport},
+			Timeout:   50 * time.Second,
+		}
+	}
+
+	return &external{
+		kube:  c.kube,
+		log:   c.log,
+		ghCli: github.NewClient(opts),
+		rec:   c.recorder,
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube  client.Client
+	log   logging.Logger
+	ghCli *github.Client
+	rec   record.EventRecorder
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	return nil // NOOP
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (reconciler.ExternalObservation, error) {
+	cr, ok := mg.(*teamv1alpha1.TeamMembers)
+	if !ok {
+		return reconciler.ExternalObservation{}, errors.New(errNotTeamMembers)
+	}
+
+	if meta.WasDeleted(cr) {
+		// The members are left unchanged on deletion.
+		return reconciler.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	p, err := e.plan(spec)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	cr.Status.PendingAdditions = p.additions()
+	cr.Status.PendingRemovals = p.removals
+	cr.Status.PendingRoleChanges = p.roleChanges()
+	cr.Status.PendingInvitations = p.invitations
+
+	cr.SetConditions(prv1.Available())
+
+	if diff := p.diff(); len(diff) > 0 {
+		e.log.Debug("Team members are not up to date", "org", spec.Org, "team", spec.TeamSlug, "diff", diff)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "NotUpToDate", "Members of team '%s/%s' differ from desired state: %s", spec.Org, spec.TeamSlug, strings.Join(diff, ", "))
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: false,
+			Diff:             strings.Join(diff, "\n"),
+		}, nil
+	}
+
+	e.log.Debug("Team members are up to date", "org", spec.Org, "team", spec.TeamSlug)
+
+	return reconciler.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+// Create is never called, since the members of a team always exist.
+func (e *external) Create(ctx context.Context, mg resource.Managed) error {
+	return e.Update(ctx, mg)
+}
+
+// Update adds the missing members and changes the roles first, then
+// removes the undesired members. Nothing is changed when the removals
+// exceed the safety threshold.
+func (e *external) Update(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*teamv1alpha1.TeamMembers)
+	if !ok {
+		return errors.New(errNotTeamMembers)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	p, err := e.plan(spec)
+	if err != nil {
+		return err
+	}
+
+	if limit := ptr.Deref(spec.MaxRemovals, defaultMaxRemovals); limit >= 0 && len(p.removals) > limit {
+		e.rec.Eventf(cr, corev1.EventTypeWarning, "TooManyRemovals", "Refusing to remove %d members from team '%s/%s' (maxRemovals: %d): %s", len(p.removals), spec.Org, spec.TeamSlug, limit, strings.Join(p.removals, ", "))
+		return fmt.Errorf("refusing to remove %d members from team '%s/%s', more than maxRemovals (%d)", len(p.removals), spec.Org, spec.TeamSlug, limit)
+	}
+
+	for _, m := range append(p.adds, p.changes...) {
+		opts := &teamv1alpha1.TeamMembershipSpec{
+			Org:      spec.Org,
+			TeamSlug: spec.TeamSlug,
+			Username: m.login,
+			Role:     ptr.To(m.role),
+		}
+		tm, err := e.ghCli.Teams().SetMembership(opts)
+		if err != nil {
+			return err
+		}
+		e.log.Debug("Team member set", "org", spec.Org, "team", spec.TeamSlug, "username", m.login, "role", tm.Role, "state", tm.State)
+	}
+
+	for _, login := range p.removals {
+		opts := &teamv1alpha1.TeamMembershipSpec{
+			Org:      spec.Org,
+			TeamSlug: spec.TeamSlug,
+			Username: login,
+		}
+		err := e.ghCli.Teams().RemoveMembership(opts)
+		if err != nil {
+			return err
+		}
+		e.log.Debug("Team member removed", "org", spec.Org, "team", spec.TeamSlug, "username", login)
+	}
+
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "TeamMembersUpdated", "Members of team '%s/%s' updated: %d added, %d changed, %d removed", spec.Org, spec.TeamSlug, len(p.adds), len(p.changes), len(p.removals))
+
+	return nil
+}
+
+// Delete leaves the members of the team unchanged.
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	return nil
+}
+
+const defaultMaxRemovals = 5
+
+type member struct {
+	login string
+	role  string
+}
+
+// plan holds the changes needed to make the members of a team match the
+// desired ones.
+type plan struct {
+	adds        []member
+	changes     []member
+	removals    []string
+	invitations []string
+}
+
+// plan compares the members of the team with the desired ones. Users
+// whose membership is pending with the desired role are not added again.
+func (e *external) plan(spec *teamv1alpha1.TeamMembersSpec) (*plan, error) {
+	desired, err := desiredMembers(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	observed := map[string]member{}
+	for _, role := range []string{teamv1alpha1.TeamRoleMember, teamv1alpha1.TeamRoleMaintainer} {
+		logins, err := e.ghCli.Teams().ListMembers(spec.Org, spec.TeamSlug, role)
+		if err != nil {
+			return nil, err
+		}
+		for _, login := range logins {
+			observed[strings.ToLower(login)] = member{login: login, role: role}
+		}
+	}
+
+	res := diffMembers(desired, observed)
+
+	var adds []member
+	for _, want := range res.adds {
+		tm, err := e.ghCli.Teams().GetMembership(&teamv1alpha1.TeamMembershipSpec{
+			Org:      spec.Org,
+			TeamSlug: spec.TeamSlug,
+			Username: want.login,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if tm != nil && tm.State == "pending" && tm.Role == want.role {
+			res.invitations = append(res.invitations, want.login)
+			continue
+		}
+		adds = append(adds, want)
+	}
+	res.adds = adds
+
+	return res, nil
+}
+
+// desiredMembers returns the desired members of the team keyed by their
+// lowercase login. A login cannot be both a member and a maintainer.
+func desiredMembers(spec *teamv1alpha1.TeamMembersSpec) (map[string]member, error) {
+	desired := map[string]member{}
+	for _, login := range spec.Members {
+		desired[strings.ToLower(login)] = member{login: login, role: teamv1alpha1.TeamRoleMember}
+	}
+	for _, login := range spec.Maintainers {
+		key := strings.ToLower(login)
+		if got, ok := desired[key]; ok && got.role == teamv1alpha1.TeamRoleMember {
+			return nil, fmt.Errorf("user '%s' is listed both as member and as maintainer of team '%s/%s'", login, spec.Org, spec.TeamSlug)
+		}
+		desired[key] = member{login: login, role: teamv1alpha1.TeamRoleMaintainer}
+	}
+	return desired, nil
+}
+
+// diffMembers compares the observed members with the desired ones, both
+// keyed by their lowercase login. The changes are sorted by login.
+func diffMembers(desired, observed map[string]member) *plan {
+	res := &plan{}
+	for key, want := range desired {
+		got, ok := observed[key]
+		if !ok {
+			res.adds = append(res.adds, want)
+			continue
+		}
+		if got.role != want.role {
+			res.changes = append(res.changes, want)
+		}
+	}
+	for key, got := range observed {
+		if _, ok := desired[key]; !ok {
+			res.removals = append(res.removals, got.login)
+		}
+	}
+
+	slices.SortFunc(res.adds, compareMembers)
+	slices.SortFunc(res.changes, compareMembers)
+	slices.Sort(res.removals)
+
+	return res
+}
+
+func (p *plan) additions() []string {
+	res := []string{}
+	for _, m := range p.adds {
+		res = append(res, fmt.Sprintf("%s (%s)", m.login, m.role))
+	}
+	return res
+}
+
+func (p *plan) roleChanges() []string {
+	res := []string{}
+	for _, m := range p.changes {
+		res = append(res, fmt.Sprintf("%s (%s)", m.login, m.role))
+	}
+	return res
+}
+
+func (p *plan) diff() []string {
+	diff := []string{}
+	if len(p.adds) > 0 {
+		diff = append(diff, fmt.Sprintf("add: %v", p.additions()))
+	}
+	if len(p.changes) > 0 {
+		diff = append(diff, fmt.Sprintf("role: %v", p.roleChanges()))
+	}
+	if len(p.removals) > 0 {
+		diff = append(diff, fmt.Sprintf("remove: %v", p.removals))
+	}
+	return diff
+}
+
+func compareMembers(a, b member) int {
+	return strings.Compare(a.login, b.login)
+}
@@ -0,0 +1,112 @@
+package teamMembers
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	teamv1alpha1 "github.com/krateoplatformops/github-provider/apis/team/v1alpha1"
+)
+
+func TestDesiredMembers(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     teamv1alpha1.TeamMembersSpec
+		expected map[string]member
+		err      bool
+	}{
+		{
+			name:     "empty",
+			spec:     teamv1alpha1.TeamMembersSpec{},
+			expected: map[string]member{},
+		},
+		{
+			name: "members and maintainers",
+			spec: teamv1alpha1.TeamMembersSpec{
+				Members:     []string{"Octocat"},
+				Maintainers: []string{"hubot"},
+			},
+			expected: map[string]member{
+				"octocat": {login: "Octocat", role: teamv1alpha1.TeamRoleMember},
+				"hubot":   {login: "hubot", role: teamv1alpha1.TeamRoleMaintainer},
+			},
+		},
+		{
+			name: "listed twice",
+			spec: teamv1alpha1.TeamMembersSpec{
+				Members:     []string{"octocat"},
+				Maintainers: []string{"OctoCat"},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := desiredMembers(&tc.spec)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestDiffMembers(t *testing.T) {
+	members := func(role string, logins ...string) map[string]member {
+		res := map[string]member{}
+		for _, login := range logins {
+			res[login] = member{login: login, role: role}
+		}
+		return res
+	}
+
+	tests := []struct {
+		name              string
+		desired, observed map[string]member
+		adds, changes     []member
+		removals          []string
+	}{
+		{
+			name:     "up to date",
+			desired:  members(teamv1alpha1.TeamRoleMember, "a", "b"),
+			observed: members(teamv1alpha1.TeamRoleMember, "b", "a"),
+		},
+		{
+			name:     "add and remove",
+			desired:  members(teamv1alpha1.TeamRoleMember, "c", "a"),
+			observed: members(teamv1alpha1.TeamRoleMember, "b", "d", "a"),
+			adds:     []member{{login: "c", role: teamv1alpha1.TeamRoleMember}},
+			removals: []string{"b", "d"},
+		},
+		{
+			name:     "role change",
+			desired:  members(teamv1alpha1.TeamRoleMaintainer, "a"),
+			observed: members(teamv1alpha1.TeamRoleMember, "a"),
+			changes:  []member{{login: "a", role: teamv1alpha1.TeamRoleMaintainer}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := diffMembers(tc.desired, tc.observed)
+			if !slices.Equal(p.adds, tc.adds) {
+				t.Errorf("adds: expected %v, got %v", tc.adds, p.adds)
+			}
+			if !slices.Equal(p.changes, tc.changes) {
+				t.Errorf("changes: expected %v, got %v", tc.changes, p.changes)
+			}
+			if !slices.Equal(p.removals, tc.removals) {
+				t.Errorf("removals: expected %v, got %v", tc.removals, p.removals)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package teamMembership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/controller"
+	"github.com/krateoplatformops/provider-runtime/pkg/ratelimiter"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/event"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/reconciler"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+
+	teamv1alpha1 "github.com/krateoplatformops/github-provider/apis/team/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+)
+
+const (
+	errNotTeamMembership = "managed resource is not a teamMembership custom resource"
+)
+
+// Setup adds a controller that reconciles Token managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := reconciler.ControllerName(teamv1alpha1.TeamMembershipGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+
+	recorder := mgr.GetEventRecorderFor(name)
+
+	r := reconciler.NewReconciler(mgr,
+		resource.ManagedKind(teamv1alpha1.TeamMembershipGroupVersionKind),
+		reconciler.WithExternalConnecter(&connector{
+			kube:     mgr.GetClient(),
+			log:      log,
+			recorder: recorder,
+		}),
+		reconciler.WithPollInterval(o.PollInterval),
+		reconciler.WithLogger(log),
+		reconciler.WithRecorder(event.NewAPIRecorder(recorder)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&teamv1alpha1.TeamMembership{}).
+		Complete(ratelimiter.New(name, r, o.GlobalRateLimiter))
+}
+
+type connector struct {
+	kube     client.Client
+	log      logging.Logger
+	recorder record.EventRecorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (reconciler.ExternalClient, error) {
+	cr, ok := mg.(*teamv1alpha1.TeamMembership)
+	if !ok {
+		return nil, errors.New(errNotTeamMembership)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	csr := spec.Credentials.SecretRef
+	if csr == nil {
+		return nil, fmt.Errorf("no credentials secret referenced")
+	}
+
+	token, err := resource.GetSecret(ctx, c.kube, csr.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := github.ClientOpts{
+		ApiURL: spec.ApiUrl,
+		Token:  token,
+	}
+	opts.HttpClient = clients.DefaultHttpClient()
+
+	if ptr.Deref(cr.Spec.Verbose, false) {
+		opts.HttpClient = &http.Client{
+			Transport: &clients.VerboseTracer{RoundTripper: http.DefaultTransport},
+			Timeout:   50 * time.Second,
+		}
+	}
+
+	return &external{
+		kube:  c.kube,
+		log:   c.log,
+		ghCli: github.NewClient(opts),
+		rec:   c.recorder,
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube  client.Client
+	log   logging.Logger
+	ghCli *github.Client
+	rec   record.EventRecorder
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	return nil // NOOP
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (reconciler.ExternalObservation, error) {
+	cr, ok := mg.(*teamv1alpha1.TeamMembership)
+	if !ok {
+		return reconciler.ExternalObservation{}, errors.New(errNotTeamMembership)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	tm, err := e.ghCli.Teams().GetMembership(spec)
+	if err != nil {
+		return reconciler.ExternalObservation{}, err
+	}
+
+	if tm == nil {
+		e.log.Debug("Team membership not found", "org", spec.Org, "team", spec.TeamSlug, "username", spec.Username)
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	cr.Status.State = ptr.To(tm.State)
+
+	if tm.State == "pending" {
+		cr.SetConditions(teamv1alpha1.Pending())
+	} else {
+		cr.SetConditions(prv1.Available())
+	}
+
+	if role := ptr.Deref(spec.Role, teamv1alpha1.TeamRoleMember); tm.Role != role {
+		e.log.Debug("Team membership is not up to date", "org", spec.Org, "team", spec.TeamSlug, "username", spec.Username, "role", tm.Role)
+		e.rec.Eventf(cr, corev1.EventTypeNormal, "NotUpToDate", "Membership of '%s' in team '%s/%s' differs from desired state: role: %s (observed: %s)", spec.Username, spec.Org, spec.TeamSlug, role, tm.Role)
+
+		return reconciler.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: false,
+			Diff:             fmt.Sprintf("role: %s (observed: %s)", role, tm.Role),
+		}, nil
+	}
+
+	e.log.Debug("Team membership already exists", "org", spec.Org, "team", spec.TeamSlug, "username", spec.Username, "state", tm.State)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "AlreadyExists", "Membership of '%s' in team '%s/%s' already exists (%s)", spec.Username, spec.Org, spec.TeamSlug, tm.State)
+
+	return reconciler.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*teamv1alpha1.TeamMembership)
+	if !ok {
+		return errors.New(errNotTeamMembership)
+	}
+
+	cr.SetConditions(prv1.Creating())
+
+	spec := cr.Spec.DeepCopy()
+
+	tm, err := e.ghCli.Teams().SetMembership(spec)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Team membership created", "org", spec.Org, "team", spec.TeamSlug, "username", spec.Username, "state", tm.State)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "TeamMembershipCreated", "Membership of '%s' in team '%s/%s' created (%s)", spec.Username, spec.Org, spec.TeamSlug, tm.State)
+
+	return nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*teamv1alpha1.TeamMembership)
+	if !ok {
+		return errors.New(errNotTeamMembership)
+	}
+
+	spec := cr.Spec.DeepCopy()
+
+	tm, err := e.ghCli.Teams().SetMembership(spec)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Team membership updated", "org", spec.Org, "team", spec.TeamSlug, "username", spec.Username, "role", tm.Role)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "TeamMembershipUpdated", "Membership of '%s' in team '%s/%s' updated", spec.Username, spec.Org, spec.TeamSlug)
+
+	return nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*teamv1alpha1.TeamMembership)
+	if !ok {
+		return errors.New(errNotTeamMembership)
+	}
+
+	cr.SetConditions(prv1.Deleting())
+
+	spec := cr.Spec.DeepCopy()
+
+	err := e.ghCli.Teams().RemoveMembership(spec)
+	if err != nil {
+		return err
+	}
+	e.log.Debug("Team membership deleted", "org", spec.Org, "team", spec.TeamSlug, "username", spec.Username)
+	e.rec.Eventf(cr, corev1.EventTypeNormal, "TeamMembershipDeleted", "Membership of '%s' in team '%s/%s' deleted", spec.Username, spec.Org, spec.TeamSlug)
+
+	return nil
+}
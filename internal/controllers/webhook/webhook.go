@@ -0,0 +1,36 @@
+// Package webhook holds the logic shared by the repoWebhook and orgWebhook
+// controllers.
+package webhook
+
+import (
+	"context"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	webhookv1alpha1 "github.com/krateoplatformops/github-provider/apis/webhook/v1alpha1"
+	"github.com/krateoplatformops/github-provider/internal/clients/fingerprint"
+	"github.com/krateoplatformops/github-provider/internal/clients/github"
+)
+
+// Secret reads the webhook secret from the referenced Secret, if any.
+func Secret(ctx context.Context, kube client.Client, spec *webhookv1alpha1.WebhookSpec) (string, error) {
+	if spec.SecretRef == nil {
+		return "", nil
+	}
+
+	return resource.GetSecret(ctx, kube, spec.SecretRef.DeepCopy())
+}
+
+// ObserveSecret compares the webhook secret with the one last written,
+// since GitHub never returns it. It returns whether the secret must be
+// written again, and whether the annotations of cr changed.
+func ObserveSecret(cr metav1.Object, key, secret string, hook *github.Webhook) (write, changed bool) {
+	return fingerprint.Observe(cr, fingerprint.Sum(key, cr.GetUID(), secret), hook.UpdatedAt, false)
+}
+
+// RecordSecret records the secret the webhook has been created with.
+func RecordSecret(cr metav1.Object, key, secret string, hook *github.Webhook) {
+	fingerprint.Record(cr, fingerprint.Sum(key, cr.GetUID(), secret), hook.UpdatedAt)
+}